@@ -0,0 +1,151 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/prune"
+)
+
+// adoptFromSelector migrates the objects pruned by the legacy label based
+// --prune to the ApplySet: the live objects created with apply which match
+// --adopt-from-selector are labeled as members of the ApplySet, and their
+// kinds and namespaces are added to the parent. Adopted objects which are
+// not part of the input are pruned by this apply, as the legacy pruner would have.
+// It must be called after ApplySet.BeforeApply.
+func (o *ApplyOptions) adoptFromSelector(ctx context.Context, infos []*resource.Info) error {
+	namespacedMappings, nonNamespacedMappings, err := prune.GetRESTMappings(o.Mapper, o.PruneResources, o.Namespace != "")
+	if err != nil {
+		return fmt.Errorf("error retrieving RESTMappings to adopt: %v", err)
+	}
+
+	for _, namespace := range o.adoptNamespaces(infos) {
+		for _, mapping := range namespacedMappings {
+			if err := o.adoptObjects(ctx, namespace, mapping); err != nil {
+				return err
+			}
+		}
+	}
+	for _, mapping := range nonNamespacedMappings {
+		if err := o.adoptObjects(ctx, metav1.NamespaceNone, mapping); err != nil {
+			return err
+		}
+	}
+
+	if err := o.ApplySet.updateParent(updateToSuperset, o.DryRunStrategy, o.ValidationDirective); err != nil {
+		return fmt.Errorf("updating ApplySet with adopted objects: %w", err)
+	}
+	return nil
+}
+
+// adoptNamespaces returns the namespaces objects are adopted from: the
+// namespaces of the objects to apply, and the one given with --namespace.
+func (o *ApplyOptions) adoptNamespaces(infos []*resource.Info) []string {
+	namespaces := sets.New[string]()
+	if o.EnforceNamespace && len(o.Namespace) > 0 {
+		namespaces.Insert(o.Namespace)
+	}
+	for _, info := range infos {
+		if info.Namespaced() {
+			namespaces.Insert(info.Namespace)
+		}
+	}
+	return sets.List(namespaces)
+}
+
+func (o *ApplyOptions) adoptObjects(ctx context.Context, namespace string, mapping *meta.RESTMapping) error {
+	list, err := o.DynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: o.AdoptFromSelector,
+	})
+	if err != nil {
+		return fmt.Errorf("listing %v objects to adopt: %w", mapping.GroupVersionKind, err)
+	}
+
+	printer, err := o.ToPrinter("adopted")
+	if err != nil {
+		return err
+	}
+	applySetID := o.ApplySet.ID()
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if _, ok := obj.GetAnnotations()[corev1.LastAppliedConfigAnnotation]; !ok {
+			// only objects created with apply were pruned by the legacy pruner
+			continue
+		}
+		info := &resource.Info{
+			Mapping:   mapping,
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			Object:    obj,
+		}
+
+		switch partOf, found := obj.GetLabels()[ApplysetPartOfLabel]; {
+		case found && partOf == applySetID:
+			// already adopted
+			o.ApplySet.adoptResource(mapping, namespace)
+			continue
+		case found:
+			fmt.Fprintf(o.ErrOut, "Warning: %s is a member of another ApplySet (%s) and will not be adopted\n", info.ObjectName(), partOf) //nolint:errcheck
+			continue
+		}
+
+		if err := o.ApplySet.AddLabels(info); err != nil {
+			return err
+		}
+		if o.DryRunStrategy != cmdutil.DryRunClient {
+			if err := o.patchMemberLabels(ctx, info); err != nil {
+				return fmt.Errorf("adopting %s: %w", info.ObjectName(), err)
+			}
+		}
+		o.ApplySet.adoptResource(mapping, namespace)
+
+		if !o.shouldPrintObject() {
+			if err := printer.PrintObj(obj, o.Out); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// patchMemberLabels adds the ApplySet member labels to the live object.
+func (o *ApplyOptions) patchMemberLabels(ctx context.Context, info *resource.Info) error {
+	patch, err := json.Marshal(map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"labels": o.ApplySet.LabelsForMember(),
+		},
+	})
+	if err != nil {
+		return err
+	}
+	options := metav1.PatchOptions{}
+	if o.DryRunStrategy == cmdutil.DryRunServer {
+		options.DryRun = []string{metav1.DryRunAll}
+	}
+	_, err = o.DynamicClient.Resource(info.Mapping.Resource).Namespace(info.Namespace).Patch(ctx, info.Name, types.MergePatchType, patch, options)
+	return err
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+func TestApplyAdoptFromSelector(t *testing.T) {
+	newRC := func(name string, labels map[string]string) *unstructured.Unstructured {
+		rc := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":       "ReplicationController",
+			"apiVersion": "v1",
+			"metadata": map[string]interface{}{
+				"name":      name,
+				"namespace": "test",
+				"uid":       "uid-" + name,
+			},
+		}}
+		rc.SetLabels(labels)
+		require.NoError(t, setLastAppliedConfigAnnotation(rc))
+		return rc
+	}
+	rcGVR := schema.GroupVersionResource{Version: "v1", Resource: "replicationcontrollers"}
+
+	tests := []struct {
+		name           string
+		alreadyAdopted bool
+		expectedOutput string
+	}{
+		{
+			name:           "adopt",
+			expectedOutput: "replicationcontroller/test-rc2 adopted\nreplicationcontroller/test-rc serverside-applied\nreplicationcontroller/test-rc2 pruned\n",
+		},
+		{
+			name:           "already adopted",
+			alreadyAdopted: true,
+			expectedOutput: "replicationcontroller/test-rc serverside-applied\nreplicationcontroller/test-rc2 pruned\n",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tf := cmdtesting.NewTestFactory().WithNamespace("test")
+			defer tf.Cleanup()
+
+			rc2Labels := map[string]string{"app": "web"}
+			if tc.alreadyAdopted {
+				rc2Labels[ApplysetPartOfLabel] = "applyset-0eFHV8ySqp7XoShsGvyWFQD3s96yqwHmzc4e0HR1dsY-v1"
+			}
+			setUpClientsForApplySetWithSSA(t, tf,
+				newRC("test-rc2", rc2Labels),
+				newRC("test-rc3", map[string]string{"app": "other"}),
+			)
+			cmdutil.BehaviorOnFatal(func(s string, i int) {
+				t.Fatalf("unexpected exit %d: %s", i, s)
+			})
+			defer cmdutil.DefaultBehaviorOnFatal()
+
+			ioStreams, _, outbuff, errbuff := genericiooptions.NewTestIOStreams()
+			cmdtesting.WithAlphaEnvs([]cmdutil.FeatureGate{cmdutil.ApplySet}, t, func(t *testing.T) {
+				cmd := NewCmdApply("kubectl", tf, ioStreams)
+				cmd.Flags().Set("filename", filenameRC)
+				cmd.Flags().Set("server-side", "true")
+				cmd.Flags().Set("applyset", "my-set")
+				cmd.Flags().Set("prune", "true")
+				cmd.Flags().Set("adopt-from-selector", "app=web")
+				cmd.Flags().Set("prune-allowlist", "core/v1/ReplicationController")
+				cmd.Run(cmd, []string{})
+			})
+			assert.Equal(t, tc.expectedOutput, outbuff.String())
+			assert.Equal(t, "", errbuff.String())
+
+			// adopted objects which are not part of the input are pruned
+			_, err := tf.FakeDynamicClient.Tracker().Get(rcGVR, "test", "test-rc2")
+			assert.True(t, apierrors.IsNotFound(err), "expected test-rc2 to be pruned, got %v", err)
+			// objects not matching the selector are left alone
+			_, err = tf.FakeDynamicClient.Tracker().Get(rcGVR, "test", "test-rc3")
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestAdoptNamespaces(t *testing.T) {
+	namespaced := &meta.RESTMapping{Scope: meta.RESTScopeNamespace}
+	clusterScoped := &meta.RESTMapping{Scope: meta.RESTScopeRoot}
+	infos := []*resource.Info{
+		{Name: "web", Namespace: "web", Mapping: namespaced},
+		{Name: "db", Namespace: "db", Mapping: namespaced},
+		{Name: "web", Mapping: clusterScoped},
+	}
+
+	tests := []struct {
+		name             string
+		namespace        string
+		enforceNamespace bool
+		expected         []string
+	}{
+		{
+			name:      "namespace of the context is not scanned",
+			namespace: "default",
+			expected:  []string{"db", "web"},
+		},
+		{
+			name:             "explicit namespace is scanned",
+			namespace:        "other",
+			enforceNamespace: true,
+			expected:         []string{"db", "other", "web"},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &ApplyOptions{Namespace: tc.namespace, EnforceNamespace: tc.enforceNamespace}
+			assert.Equal(t, tc.expected, o.adoptNamespaces(infos))
+		})
+	}
+}
@@ -17,10 +17,12 @@ limitations under the License.
 package apply
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/spf13/cobra"
 	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
@@ -30,6 +32,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilerrors "k8s.io/apimachinery/pkg/util/errors"
@@ -72,8 +75,15 @@ type ApplyFlags struct {
 	Overwrite      bool
 	OpenAPIPatch   bool
 	Subresource    string
+	Ordered        bool
 
-	PruneAllowlist []string
+	WaitForReady        bool
+	WaitForReadyTimeout time.Duration
+
+	PruneAllowlist    []string
+	PruneMaxDeletions int
+	PruneConfirm      bool
+	AdoptFromSelector string
 
 	genericiooptions.IOStreams
 }
@@ -99,6 +109,21 @@ type ApplyOptions struct {
 	Overwrite       bool
 	OpenAPIPatch    bool
 	Subresource     string
+	Ordered         bool
+	// WaitForReady waits after apply and prune until the applied objects
+	// are ready and the pruned objects are gone.
+	WaitForReady        bool
+	WaitForReadyTimeout time.Duration
+
+	// PruneMaxDeletions is the largest number of objects prune may delete,
+	// or a negative number for no limit.
+	PruneMaxDeletions int
+	// ConfirmPrune asks for confirmation before the objects are pruned. It
+	// defaults to whether stdin is a terminal.
+	ConfirmPrune bool
+	// AdoptFromSelector selects the objects pruned by the legacy label based
+	// prune which are adopted as members of the ApplySet.
+	AdoptFromSelector string
 
 	ValidationDirective string
 	Validator           validation.Schema
@@ -108,6 +133,11 @@ type ApplyOptions struct {
 	OpenAPIGetter       openapi.OpenAPIResourcesGetter
 	OpenAPIV3Root       openapi3.Root
 
+	// newBuilder returns a new resource builder, used by --ordered to read
+	// the objects again once the CustomResourceDefinitions defining their
+	// kinds are established.
+	newBuilder func() *resource.Builder
+
 	Namespace        string
 	EnforceNamespace bool
 
@@ -122,12 +152,18 @@ type ApplyOptions struct {
 	// not call the resource builder; only return the set objects.
 	objects       []*resource.Info
 	objectsCached bool
+	// stdin holds the objects read from stdin with --ordered, so that
+	// they can be read again.
+	stdin []byte
 
 	// Stores visited objects/namespaces for later use
 	// calculating the set of objects to prune.
 	VisitedUids       sets.Set[types.UID]
 	VisitedNamespaces sets.Set[string]
 
+	// prunedObjects records the objects deleted by prune, for --wait-for-ready.
+	prunedObjects []PruneObject
+
 	// Function run after the objects are generated and
 	// stored in the "objects" field, but before the
 	// apply is run on these objects.
@@ -164,6 +200,9 @@ var (
 		# Apply the configuration from all files that end with '.json'
 		kubectl apply -f '*.json'
 
+		# Apply the objects of manifest.yaml in input order, instead of applying namespaces and CRDs first
+		kubectl apply --ordered=false -f manifest.yaml
+
 		# Note: --prune is still in Alpha
 		# Apply the configuration in manifest.yaml that matches label app=nginx and delete all other resources that are not in the file and match label app=nginx
 		kubectl apply --prune -f manifest.yaml -l app=nginx
@@ -189,6 +228,10 @@ func NewApplyFlags(streams genericiooptions.IOStreams) *ApplyFlags {
 
 		Overwrite:    true,
 		OpenAPIPatch: true,
+		Ordered:      true,
+
+		PruneMaxDeletions:   -1,
+		WaitForReadyTimeout: defaultWaitTimeout,
 
 		IOStreams: streams,
 	}
@@ -218,6 +261,7 @@ func NewCmdApply(baseName string, f cmdutil.Factory, ioStreams genericiooptions.
 	cmd.AddCommand(NewCmdApplyViewLastApplied(f, flags.IOStreams))
 	cmd.AddCommand(NewCmdApplySetLastApplied(f, flags.IOStreams))
 	cmd.AddCommand(NewCmdApplyEditLastApplied(f, flags.IOStreams))
+	cmd.AddCommand(NewCmdApplyMigrateOwnership(f, flags.IOStreams))
 
 	return cmd
 }
@@ -238,6 +282,14 @@ func (flags *ApplyFlags) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVar(&flags.Overwrite, "overwrite", flags.Overwrite, "Automatically resolve conflicts between the modified and live configuration by using values from the modified configuration")
 	cmd.Flags().BoolVar(&flags.OpenAPIPatch, "openapi-patch", flags.OpenAPIPatch, "If true, use openapi to calculate diff when the openapi presents and the resource can be found in the openapi spec. Otherwise, fall back to use baked-in types.")
 	cmdutil.AddSubresourceFlags(cmd, &flags.Subresource, "If specified, apply will operate on the subresource of the requested object.  Only allowed when using --server-side.")
+	cmd.Flags().IntVar(&flags.PruneMaxDeletions, "prune-max-deletions", flags.PruneMaxDeletions, "If non-negative, abort pruning when more objects than this would be deleted. Objects annotated with "+PruneProtectAnnotation+"=true are never pruned.")
+	cmd.Flags().BoolVar(&flags.PruneConfirm, "prune-confirm", flags.PruneConfirm, "If true, list the objects prune is about to delete and ask for confirmation before deleting them. Defaults to true when stdin is a terminal, use --prune-confirm=false to prune without asking.")
+	if cmdutil.ApplySet.IsEnabled() {
+		cmd.Flags().StringVar(&flags.AdoptFromSelector, "adopt-from-selector", flags.AdoptFromSelector, "[alpha] Label selector used with the label based --prune. Live objects created with apply which match it are adopted as members of the ApplySet given with --applyset, so that they are pruned by the ApplySet from then on. Use with --prune-allowlist to adopt objects of other types than the default allowlist.")
+	}
+	cmd.Flags().BoolVar(&flags.WaitForReady, "wait-for-ready", flags.WaitForReady, "If true, wait after apply and prune until the applied objects are ready and the pruned objects are gone: Deployments, DaemonSets and StatefulSets rolled out, Jobs complete, Pods ready and other objects reporting a Ready condition.")
+	cmd.Flags().DurationVar(&flags.WaitForReadyTimeout, "wait-for-ready-timeout", flags.WaitForReadyTimeout, "The length of time to wait for the objects with --wait-for-ready before giving up.")
+	cmd.Flags().BoolVar(&flags.Ordered, "ordered", flags.Ordered, "If true, apply objects in dependency order: Namespaces, then CustomResourceDefinitions, then cluster RBAC, then all other objects, then admission webhooks. CustomResourceDefinitions are waited on to be established before the objects of their kinds are applied. Set --ordered=false to apply objects in input order.")
 }
 
 // ToOptions converts from CLI inputs to runtime inputs
@@ -337,6 +389,10 @@ func (flags *ApplyFlags) ToOptions(f cmdutil.Factory, cmd *cobra.Command, baseNa
 			return nil, err
 		}
 	}
+	confirmPrune := flags.Prune && isTerminalIn(flags.In)
+	if cmd.Flags().Changed("prune-confirm") {
+		confirmPrune = flags.PruneConfirm
+	}
 
 	o := &ApplyOptions{
 		// 	Store baseName for use in printing warnings / messages involving the base command name.
@@ -358,6 +414,14 @@ func (flags *ApplyFlags) ToOptions(f cmdutil.Factory, cmd *cobra.Command, baseNa
 		Overwrite:       flags.Overwrite,
 		OpenAPIPatch:    flags.OpenAPIPatch,
 		Subresource:     flags.Subresource,
+		Ordered:         flags.Ordered,
+
+		WaitForReady:        flags.WaitForReady,
+		WaitForReadyTimeout: flags.WaitForReadyTimeout,
+
+		PruneMaxDeletions: flags.PruneMaxDeletions,
+		ConfirmPrune:      confirmPrune,
+		AdoptFromSelector: flags.AdoptFromSelector,
 
 		Recorder:            recorder,
 		Namespace:           namespace,
@@ -365,6 +429,7 @@ func (flags *ApplyFlags) ToOptions(f cmdutil.Factory, cmd *cobra.Command, baseNa
 		Validator:           validator,
 		ValidationDirective: validationDirective,
 		Builder:             builder,
+		newBuilder:          f.NewBuilder,
 		Mapper:              mapper,
 		DynamicClient:       dynamicClient,
 		OpenAPIGetter:       f,
@@ -416,6 +481,20 @@ func (o *ApplyOptions) Validate() error {
 			return err
 		}
 	}
+	if len(o.AdoptFromSelector) > 0 {
+		if o.ApplySet == nil {
+			return fmt.Errorf("--adopt-from-selector requires --applyset")
+		}
+		if _, err := labels.Parse(o.AdoptFromSelector); err != nil {
+			return fmt.Errorf("invalid --adopt-from-selector: %w", err)
+		}
+	}
+	if o.PruneMaxDeletions >= 0 && !o.Prune {
+		return fmt.Errorf("--prune-max-deletions requires --prune")
+	}
+	if o.ConfirmPrune && !o.Prune {
+		return fmt.Errorf("--prune-confirm requires --prune")
+	}
 	if o.Prune {
 		// Do not force the recreation of an object(s) if we're pruning; this can cause
 		// undefined behavior since object UID's change.
@@ -428,7 +507,7 @@ func (o *ApplyOptions) Validate() error {
 				return fmt.Errorf("--all is incompatible with --applyset")
 			} else if o.Selector != "" {
 				return fmt.Errorf("--selector is incompatible with --applyset")
-			} else if len(o.PruneResources) > 0 {
+			} else if len(o.PruneResources) > 0 && len(o.AdoptFromSelector) == 0 {
 				return fmt.Errorf("--prune-allowlist is incompatible with --applyset")
 			}
 		} else {
@@ -466,18 +545,7 @@ func isIncompatibleServerError(err error) bool {
 func (o *ApplyOptions) GetObjects() ([]*resource.Info, error) {
 	var err error = nil
 	if !o.objectsCached {
-		r := o.Builder.
-			Unstructured().
-			Schema(o.Validator).
-			ContinueOnError().
-			NamespaceParam(o.Namespace).DefaultNamespace().
-			FilenameParam(o.EnforceNamespace, &o.DeleteOptions.FilenameOptions).
-			LabelSelectorParam(o.Selector).
-			Flatten().
-			Do()
-
-		o.objects, err = r.Infos()
-
+		o.objects, err = o.readObjects(o.Builder)
 		if o.ApplySet != nil {
 			if err := o.ApplySet.AddLabels(o.objects...); err != nil {
 				return nil, err
@@ -489,6 +557,45 @@ func (o *ApplyOptions) GetObjects() ([]*resource.Info, error) {
 	return o.objects, err
 }
 
+// readObjects reads the objects to apply with the given builder. With
+// --ordered, stdin is kept so that the objects can be read again.
+func (o *ApplyOptions) readObjects(builder *resource.Builder) ([]*resource.Info, error) {
+	filenameOptions := o.DeleteOptions.FilenameOptions
+	readStdin := false
+	if o.Ordered {
+		filenameOptions.Filenames = nil
+		for _, filename := range o.DeleteOptions.FilenameOptions.Filenames {
+			if filename == "-" {
+				readStdin = true
+				continue
+			}
+			filenameOptions.Filenames = append(filenameOptions.Filenames, filename)
+		}
+	}
+	if readStdin && o.stdin == nil {
+		data, err := io.ReadAll(o.In)
+		if err != nil {
+			return nil, err
+		}
+		o.stdin = data
+	}
+
+	builder = builder.
+		Unstructured().
+		Schema(o.Validator).
+		ContinueOnError().
+		NamespaceParam(o.Namespace).DefaultNamespace().
+		FilenameParam(o.EnforceNamespace, &filenameOptions)
+	if readStdin {
+		builder = builder.Stream(bytes.NewReader(o.stdin), "STDIN")
+	}
+	return builder.
+		LabelSelectorParam(o.Selector).
+		Flatten().
+		Do().
+		Infos()
+}
+
 // SetObjects stores the set of objects (as resource.Info) to be
 // subsequently applied.
 func (o *ApplyOptions) SetObjects(infos []*resource.Info) {
@@ -525,12 +632,23 @@ func (o *ApplyOptions) Run() error {
 		if err := o.ApplySet.BeforeApply(infos, o.DryRunStrategy, o.ValidationDirective); err != nil {
 			return err
 		}
+		if len(o.AdoptFromSelector) > 0 {
+			if err := o.adoptFromSelector(context.TODO(), infos); err != nil {
+				return err
+			}
+		}
 	}
 
-	// Iterate through all objects, applying each one.
-	for _, info := range infos {
-		if err := o.applyOneObject(info); err != nil {
-			errs = append(errs, err)
+	if o.Ordered {
+		// Apply the objects in dependency order, reading the objects
+		// whose kinds were not served yet again after their CRDs are established.
+		errs = o.applyInPhases(infos, err)
+	} else {
+		// Iterate through all objects, applying each one.
+		for _, info := range infos {
+			if err := o.applyOneObject(info); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	}
 	// If any errors occurred during apply, then return error (or
@@ -1077,10 +1195,16 @@ func (o *ApplyOptions) PrintAndPrunePostProcessor() func() error {
 				}
 			} else {
 				p := newPruner(o)
-				return p.pruneAll(o)
+				if err := p.pruneAll(o); err != nil {
+					return err
+				}
+				o.prunedObjects = p.pruned
 			}
 		}
 
+		if o.WaitForReady && o.DryRunStrategy == cmdutil.DryRunNone {
+			return o.waitForObjects(ctx)
+		}
 		return nil
 	}
 }
@@ -0,0 +1,615 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+	"sigs.k8s.io/structured-merge-diff/v6/value"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/util/csaupgrade"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/kubectl/pkg/util/completion"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+const (
+	migrateToServerSide = "server-side"
+	migrateToClientSide = "client-side"
+)
+
+// MigrateOwnershipOptions defines options for the `apply migrate-ownership` command.
+type MigrateOwnershipOptions struct {
+	To           string
+	FieldManager string
+	Selector     string
+	All          bool
+
+	PrintFlags *genericclioptions.PrintFlags
+	PrintObj   printers.ResourcePrinterFunc
+
+	FilenameOptions resource.FilenameOptions
+
+	args                         []string
+	namespace                    string
+	enforceNamespace             bool
+	dryRunStrategy               cmdutil.DryRunStrategy
+	output                       string
+	builder                      *resource.Builder
+	unstructuredClientForMapping func(mapping *meta.RESTMapping) (resource.RESTClient, error)
+
+	genericiooptions.IOStreams
+}
+
+// ownerChange is a field which changes its owner in a migration.
+type ownerChange struct {
+	path string
+	from string
+	to   string
+}
+
+var (
+	applyMigrateOwnershipLong = templates.LongDesc(i18n.T(`
+		Migrate the field ownership of live objects between client-side and server-side apply.
+
+		With --to=server-side, the fields owned by client-side apply are transferred to the
+		server-side apply field manager and the last-applied-configuration annotation is removed,
+		so that the objects can be managed with 'kubectl apply --server-side' without conflicts
+		or stale client-side apply managers.
+
+		With --to=client-side, the last-applied-configuration annotation is rebuilt from the
+		fields owned by the server-side apply field manager, and those fields are transferred
+		to client-side apply, so that the objects can be managed with 'kubectl apply' again.
+
+		The fields which change owners are reported for each object.`))
+
+	applyMigrateOwnershipExample = templates.Examples(i18n.T(`
+		# Migrate the objects in a file from client-side apply to server-side apply
+		kubectl apply migrate-ownership -f deploy.yaml --to=server-side
+
+		# Migrate a deployment to server-side apply with a custom field manager
+		kubectl apply migrate-ownership deployment/nginx --to=server-side --field-manager=my-manager
+
+		# Preview migrating the deployments labeled app=nginx back to client-side apply
+		kubectl apply migrate-ownership deployment -l app=nginx --to=client-side --dry-run=server`))
+)
+
+// NewMigrateOwnershipOptions takes option arguments from a CLI stream and returns it at MigrateOwnershipOptions type.
+func NewMigrateOwnershipOptions(ioStreams genericiooptions.IOStreams) *MigrateOwnershipOptions {
+	return &MigrateOwnershipOptions{
+		FieldManager: fieldManagerServerSideApply,
+		PrintFlags:   genericclioptions.NewPrintFlags("migrated").WithTypeSetter(scheme.Scheme),
+		IOStreams:    ioStreams,
+	}
+}
+
+// NewCmdApplyMigrateOwnership creates the cobra CLI `apply` subcommand `migrate-ownership`.
+func NewCmdApplyMigrateOwnership(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
+	o := NewMigrateOwnershipOptions(ioStreams)
+	cmd := &cobra.Command{
+		Use:                   "migrate-ownership (TYPE [NAME | -l label] | TYPE/NAME | -f FILENAME) --to=server-side|client-side",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Migrate the field ownership of objects between client-side and server-side apply"),
+		Long:                  applyMigrateOwnershipLong,
+		Example:               applyMigrateOwnershipExample,
+		ValidArgsFunction:     completion.ResourceTypeAndNameCompletionFunc(f),
+		Run: func(cmd *cobra.Command, args []string) {
+			cmdutil.CheckErr(o.Complete(f, cmd, args))
+			cmdutil.CheckErr(o.Validate())
+			cmdutil.CheckErr(o.RunMigrateOwnership())
+		},
+	}
+
+	o.PrintFlags.AddFlags(cmd)
+
+	cmdutil.AddDryRunFlag(cmd)
+	cmd.Flags().StringVar(&o.To, "to", o.To, "The apply mode to migrate the field ownership to. Must be one of (server-side, client-side).")
+	cmd.Flags().StringVar(&o.FieldManager, "field-manager", o.FieldManager, "Name of the server-side apply field manager to migrate the field ownership to or from.")
+	cmd.Flags().BoolVar(&o.All, "all", o.All, "Select all resources in the namespace of the specified resource types")
+	cmdutil.AddFilenameOptionFlags(cmd, &o.FilenameOptions, "containing the objects to migrate")
+	cmdutil.AddLabelSelectorFlagVar(cmd, &o.Selector)
+
+	return cmd
+}
+
+// Complete populates dry-run and output flag options.
+func (o *MigrateOwnershipOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
+	var err error
+	o.dryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+	if err != nil {
+		return err
+	}
+	o.output = cmdutil.GetFlagString(cmd, "output")
+
+	o.namespace, o.enforceNamespace, err = f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	o.args = args
+	o.builder = f.NewBuilder()
+	o.unstructuredClientForMapping = f.UnstructuredClientForMapping
+
+	cmdutil.PrintFlagsWithDryRunStrategy(o.PrintFlags, o.dryRunStrategy)
+	printer, err := o.PrintFlags.ToPrinter()
+	if err != nil {
+		return err
+	}
+	o.PrintObj = printer.PrintObj
+
+	return nil
+}
+
+// Validate checks MigrateOwnershipOptions for validity.
+func (o *MigrateOwnershipOptions) Validate() error {
+	switch o.To {
+	case migrateToServerSide, migrateToClientSide:
+	case "":
+		return fmt.Errorf("--to is required, must be one of (%s, %s)", migrateToServerSide, migrateToClientSide)
+	default:
+		return fmt.Errorf("invalid --to %q, must be one of (%s, %s)", o.To, migrateToServerSide, migrateToClientSide)
+	}
+	if len(o.FieldManager) == 0 {
+		return fmt.Errorf("--field-manager must not be empty")
+	}
+	if o.FieldManager == FieldManagerClientSideApply {
+		return fmt.Errorf("--field-manager must not be %s, which is used by client-side apply", FieldManagerClientSideApply)
+	}
+	return nil
+}
+
+// RunMigrateOwnership executes the `migrate-ownership` command according to MigrateOwnershipOptions.
+func (o *MigrateOwnershipOptions) RunMigrateOwnership() error {
+	r := o.builder.
+		Unstructured().
+		NamespaceParam(o.namespace).DefaultNamespace().
+		FilenameParam(o.enforceNamespace, &o.FilenameOptions).
+		ResourceTypeOrNameArgs(o.enforceNamespace, o.args...).
+		SelectAllParam(o.All).
+		LabelSelectorParam(o.Selector).
+		Latest().
+		Flatten().
+		Do()
+	if err := r.Err(); err != nil {
+		return err
+	}
+
+	return r.Visit(func(info *resource.Info, err error) error {
+		if err != nil {
+			return err
+		}
+		return o.migrate(info)
+	})
+}
+
+// migrate migrates the field ownership of a single live object, retrying
+// when the object was changed in the meantime.
+func (o *MigrateOwnershipOptions) migrate(info *resource.Info) error {
+	mapping := info.ResourceMapping()
+	client, err := o.unstructuredClientForMapping(mapping)
+	if err != nil {
+		return err
+	}
+	helper := resource.
+		NewHelper(client, mapping).
+		DryRun(o.dryRunStrategy == cmdutil.DryRunServer)
+
+	for i := 0; i < maxPatchRetry; i++ {
+		var patch []byte
+		var changes []ownerChange
+		patch, changes, err = o.migrationPatch(info.Object)
+		if err != nil {
+			return cmdutil.AddSourceToErr(fmt.Sprintf("migrating %s", info.ObjectName()), info.Source, err)
+		}
+		if patch == nil {
+			fmt.Fprintf(o.Out, "migrate-ownership %s: no changes required.\n", info.ObjectName()) //nolint:errcheck
+			return nil
+		}
+
+		finalObj := info.Object
+		if o.dryRunStrategy != cmdutil.DryRunClient {
+			finalObj, err = helper.Patch(info.Namespace, info.Name, types.JSONPatchType, patch, nil)
+			if errors.IsConflict(err) {
+				if err = info.Get(); err != nil {
+					return err
+				}
+				continue
+			}
+			if err != nil {
+				return err
+			}
+		}
+
+		if err := o.PrintObj(finalObj, o.Out); err != nil {
+			return err
+		}
+		if len(o.output) == 0 || o.output == "name" {
+			for _, c := range changes {
+				fmt.Fprintf(o.Out, "  %s: %s -> %s\n", c.path, c.from, c.to) //nolint:errcheck
+			}
+		}
+		return nil
+	}
+
+	// Reaching this point means the object kept conflicting until the
+	// retries ran out, return the last conflict.
+	return err
+}
+
+// migrationPatch returns the JSON patch migrating the field ownership of obj,
+// together with the fields changing owners, or a nil patch when obj is
+// already migrated.
+func (o *MigrateOwnershipOptions) migrationPatch(obj runtime.Object) ([]byte, []ownerChange, error) {
+	if o.To == migrateToClientSide {
+		return o.clientSidePatch(obj)
+	}
+	return o.serverSidePatch(obj)
+}
+
+// serverSidePatch transfers the fields owned by the client-side apply managers
+// to the server-side apply field manager, and removes the last-applied-configuration
+// annotation.
+func (o *MigrateOwnershipOptions) serverSidePatch(obj runtime.Object) ([]byte, []ownerChange, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	managedFields := accessor.GetManagedFields()
+
+	// The client-side apply managers are the Update managers owning the
+	// last-applied-configuration annotation, as in migrateToSSAIfNecessary,
+	// and the default client-side apply manager which may be left over from
+	// earlier applies.
+	managers := sets.New[string]()
+	for _, entry := range csaupgrade.FindFieldsOwners(managedFields, metav1.ManagedFieldsOperationUpdate, lastAppliedAnnotationFieldPath) {
+		managers.Insert(entry.Manager)
+	}
+	for _, entry := range managedFields {
+		if entry.Manager == FieldManagerClientSideApply && entry.Operation == metav1.ManagedFieldsOperationUpdate {
+			managers.Insert(entry.Manager)
+		}
+	}
+
+	owned := fieldpath.NewSet()
+	for _, entry := range managedFields {
+		if entry.Manager == o.FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply && len(entry.Subresource) == 0 {
+			set, err := managedFieldsSet(entry)
+			if err != nil {
+				return nil, nil, err
+			}
+			owned = owned.Union(set)
+		}
+	}
+	var changes []ownerChange
+	for _, entry := range managedFields {
+		if !managers.Has(entry.Manager) || entry.Operation != metav1.ManagedFieldsOperationUpdate || len(entry.Subresource) > 0 {
+			continue
+		}
+		set, err := managedFieldsSet(entry)
+		if err != nil {
+			return nil, nil, err
+		}
+		set.Leaves().Difference(owned).Difference(lastAppliedAnnotationFieldPath).Iterate(func(p fieldpath.Path) {
+			changes = append(changes, ownerChange{path: p.String(), from: entry.Manager, to: o.FieldManager})
+		})
+	}
+
+	var ops []map[string]interface{}
+	if managers.Len() > 0 {
+		upgrade, err := csaupgrade.UpgradeManagedFieldsPatch(obj, managers, o.FieldManager)
+		if err != nil {
+			return nil, nil, err
+		}
+		if upgrade != nil {
+			if err := json.Unmarshal(upgrade, &ops); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	if _, ok := accessor.GetAnnotations()[corev1.LastAppliedConfigAnnotation]; ok {
+		if len(ops) == 0 {
+			ops = append(ops, resourceVersionPrecondition(accessor))
+		}
+		ops = append(ops, map[string]interface{}{
+			"op":   "remove",
+			"path": "/metadata/annotations/" + escapeJSONPointer(corev1.LastAppliedConfigAnnotation),
+		})
+	}
+	if len(ops) == 0 {
+		return nil, nil, nil
+	}
+
+	patch, err := json.Marshal(ops)
+	if err != nil {
+		return nil, nil, err
+	}
+	sortOwnerChanges(changes)
+	return patch, changes, nil
+}
+
+// clientSidePatch rebuilds the last-applied-configuration annotation from the
+// fields owned by the server-side apply field manager, and transfers those
+// fields to client-side apply.
+func (o *MigrateOwnershipOptions) clientSidePatch(obj runtime.Object) ([]byte, []ownerChange, error) {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil, nil, err
+	}
+	u, ok := obj.(runtime.Unstructured)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected object type %T", obj)
+	}
+
+	owned := fieldpath.NewSet()
+	csaOwned := fieldpath.NewSet()
+	apiVersion := ""
+	var managedFields []metav1.ManagedFieldsEntry
+	for _, entry := range accessor.GetManagedFields() {
+		if len(entry.Subresource) == 0 {
+			switch {
+			case entry.Manager == o.FieldManager && entry.Operation == metav1.ManagedFieldsOperationApply:
+				set, err := managedFieldsSet(entry)
+				if err != nil {
+					return nil, nil, err
+				}
+				owned = owned.Union(set)
+				apiVersion = entry.APIVersion
+				continue
+			case entry.Manager == FieldManagerClientSideApply && entry.Operation == metav1.ManagedFieldsOperationUpdate:
+				set, err := managedFieldsSet(entry)
+				if err != nil {
+					return nil, nil, err
+				}
+				csaOwned = csaOwned.Union(set)
+				continue
+			}
+		}
+		managedFields = append(managedFields, entry)
+	}
+	if owned.Empty() {
+		return nil, nil, nil
+	}
+
+	var changes []ownerChange
+	owned.Leaves().Difference(csaOwned).Difference(lastAppliedAnnotationFieldPath).Iterate(func(p fieldpath.Path) {
+		changes = append(changes, ownerChange{path: p.String(), from: o.FieldManager, to: FieldManagerClientSideApply})
+	})
+
+	lastApplied, err := lastAppliedFromOwnedFields(u.UnstructuredContent(), owned)
+	if err != nil {
+		return nil, nil, err
+	}
+	fields, err := owned.Union(csaOwned).Union(lastAppliedAnnotationFieldPath).ToJSON()
+	if err != nil {
+		return nil, nil, err
+	}
+	now := metav1.Now()
+	managedFields = append(managedFields, metav1.ManagedFieldsEntry{
+		Manager:    FieldManagerClientSideApply,
+		Operation:  metav1.ManagedFieldsOperationUpdate,
+		APIVersion: apiVersion,
+		Time:       &now,
+		FieldsType: "FieldsV1",
+		FieldsV1:   &metav1.FieldsV1{Raw: fields},
+	})
+
+	annotations := map[string]string{}
+	for k, v := range accessor.GetAnnotations() {
+		annotations[k] = v
+	}
+	annotations[corev1.LastAppliedConfigAnnotation] = string(lastApplied)
+
+	patch, err := json.Marshal([]map[string]interface{}{
+		resourceVersionPrecondition(accessor),
+		{"op": "replace", "path": "/metadata/managedFields", "value": managedFields},
+		{"op": "add", "path": "/metadata/annotations", "value": annotations},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	sortOwnerChanges(changes)
+	return patch, changes, nil
+}
+
+// lastAppliedFromOwnedFields returns the last-applied-configuration of an
+// object made of the fields in owned, as client-side apply would have recorded it.
+func lastAppliedFromOwnedFields(obj map[string]interface{}, owned *fieldpath.Set) ([]byte, error) {
+	extracted, _ := newOwnedFields(owned).extract(obj)
+	config, _ := extracted.(map[string]interface{})
+	if config == nil {
+		config = map[string]interface{}{}
+	}
+	lastApplied := &unstructured.Unstructured{Object: config}
+	source := &unstructured.Unstructured{Object: obj}
+	lastApplied.SetAPIVersion(source.GetAPIVersion())
+	lastApplied.SetKind(source.GetKind())
+	lastApplied.SetName(source.GetName())
+	lastApplied.SetNamespace(source.GetNamespace())
+	if annotations := lastApplied.GetAnnotations(); annotations != nil {
+		delete(annotations, corev1.LastAppliedConfigAnnotation)
+		lastApplied.SetAnnotations(annotations)
+	}
+	return runtime.Encode(unstructured.UnstructuredJSONScheme, lastApplied)
+}
+
+// ownedFields is a fieldpath.Set arranged as a tree, so that the fields of
+// an object can be matched while walking it.
+type ownedFields struct {
+	member   bool
+	children []ownedChild
+}
+
+type ownedChild struct {
+	element fieldpath.PathElement
+	fields  *ownedFields
+}
+
+func newOwnedFields(set *fieldpath.Set) *ownedFields {
+	root := &ownedFields{}
+	set.Iterate(func(p fieldpath.Path) {
+		node := root
+		for _, pe := range p {
+			node = node.child(pe)
+		}
+		node.member = true
+	})
+	return root
+}
+
+func (f *ownedFields) child(pe fieldpath.PathElement) *ownedFields {
+	for _, c := range f.children {
+		if c.element.Equals(pe) {
+			return c.fields
+		}
+	}
+	c := ownedChild{element: pe, fields: &ownedFields{}}
+	f.children = append(f.children, c)
+	return c.fields
+}
+
+// extract returns the parts of v covered by f, and whether anything was covered.
+// Fields without owned children are owned as a whole.
+func (f *ownedFields) extract(v interface{}) (interface{}, bool) {
+	if len(f.children) == 0 {
+		return v, f.member
+	}
+	switch t := v.(type) {
+	case map[string]interface{}:
+		out := map[string]interface{}{}
+		for k, fieldValue := range t {
+			for _, c := range f.children {
+				if c.element.FieldName == nil || *c.element.FieldName != k {
+					continue
+				}
+				if extracted, ok := c.fields.extract(fieldValue); ok {
+					out[k] = extracted
+				}
+				break
+			}
+		}
+		return out, len(out) > 0 || f.member
+	case []interface{}:
+		var out []interface{}
+		for i, item := range t {
+			for _, c := range f.children {
+				if !listItemMatches(c.element, i, item) {
+					continue
+				}
+				if extracted, ok := c.fields.extract(item); ok {
+					out = append(out, withListKeys(c.element, extracted, item))
+				}
+				break
+			}
+		}
+		return out, len(out) > 0 || f.member
+	default:
+		return v, f.member
+	}
+}
+
+// listItemMatches returns whether pe identifies the i-th item of a list.
+func listItemMatches(pe fieldpath.PathElement, i int, item interface{}) bool {
+	switch {
+	case pe.Index != nil:
+		return *pe.Index == i
+	case pe.Value != nil:
+		return value.Equals(*pe.Value, value.NewValueInterface(item))
+	case pe.Key != nil:
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for _, field := range *pe.Key {
+			v, ok := m[field.Name]
+			if !ok || !value.Equals(field.Value, value.NewValueInterface(v)) {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// withListKeys adds the key fields of a keyed list item, which identify the
+// item in the list, from the live item to the extracted item.
+func withListKeys(pe fieldpath.PathElement, extracted, item interface{}) interface{} {
+	m, ok := extracted.(map[string]interface{})
+	if !ok || pe.Key == nil {
+		return extracted
+	}
+	live := item.(map[string]interface{})
+	for _, field := range *pe.Key {
+		if _, ok := m[field.Name]; !ok {
+			m[field.Name] = live[field.Name]
+		}
+	}
+	return m
+}
+
+func managedFieldsSet(entry metav1.ManagedFieldsEntry) (*fieldpath.Set, error) {
+	set := fieldpath.NewSet()
+	if entry.FieldsV1 == nil {
+		return set, nil
+	}
+	if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+		return nil, fmt.Errorf("decoding managed fields of %s: %w", entry.Manager, err)
+	}
+	return set, nil
+}
+
+// resourceVersionPrecondition makes a JSON patch fail with a conflict when
+// the object was changed since it was read.
+func resourceVersionPrecondition(accessor metav1.Object) map[string]interface{} {
+	return map[string]interface{}{
+		"op":    "replace",
+		"path":  "/metadata/resourceVersion",
+		"value": accessor.GetResourceVersion(),
+	}
+}
+
+// escapeJSONPointer escapes a key for use in a JSON pointer, see RFC 6901.
+func escapeJSONPointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func sortOwnerChanges(changes []ownerChange) {
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].path != changes[j].path {
+			return changes[i].path < changes[j].path
+		}
+		return changes[i].from < changes[j].from
+	})
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func applyMigrationPatch(t *testing.T, obj *unstructured.Unstructured, patch []byte) *unstructured.Unstructured {
+	t.Helper()
+	data, err := json.Marshal(obj.Object)
+	require.NoError(t, err)
+	decoded, err := jsonpatch.DecodePatch(patch)
+	require.NoError(t, err)
+	patched, err := decoded.Apply(data)
+	require.NoError(t, err)
+	result := &unstructured.Unstructured{}
+	require.NoError(t, json.Unmarshal(patched, &result.Object))
+	return result
+}
+
+func managersOf(obj *unstructured.Unstructured) map[string]metav1.ManagedFieldsOperationType {
+	managers := map[string]metav1.ManagedFieldsOperationType{}
+	for _, entry := range obj.GetManagedFields() {
+		if len(entry.Subresource) == 0 {
+			managers[entry.Manager] = entry.Operation
+		}
+	}
+	return managers
+}
+
+func TestMigrateOwnershipRoundTrip(t *testing.T) {
+	live := readUnstructuredFromFile(t, filenameRCManagedFieldsLA)
+
+	toServerSide := &MigrateOwnershipOptions{To: migrateToServerSide, FieldManager: "kubectl"}
+	patch, changes, err := toServerSide.migrationPatch(live)
+	require.NoError(t, err)
+	require.NotNil(t, patch)
+	assert.Contains(t, changes, ownerChange{path: ".spec.replicas", from: FieldManagerClientSideApply, to: "kubectl"})
+	assert.Contains(t, changes, ownerChange{path: `.spec.template.spec.containers[name="test-rc"].image`, from: FieldManagerClientSideApply, to: "kubectl"})
+	for _, c := range changes {
+		assert.NotEqual(t, ".metadata.annotations.kubectl.kubernetes.io/last-applied-configuration", c.path)
+	}
+
+	serverSide := applyMigrationPatch(t, live, patch)
+	assert.NotContains(t, serverSide.GetAnnotations(), corev1.LastAppliedConfigAnnotation)
+	assert.Equal(t, map[string]metav1.ManagedFieldsOperationType{"kubectl": metav1.ManagedFieldsOperationApply}, managersOf(serverSide))
+
+	// migrating again has nothing to do
+	patch, _, err = toServerSide.migrationPatch(serverSide)
+	require.NoError(t, err)
+	assert.Nil(t, patch)
+
+	toClientSide := &MigrateOwnershipOptions{To: migrateToClientSide, FieldManager: "kubectl"}
+	patch, changes, err = toClientSide.migrationPatch(serverSide)
+	require.NoError(t, err)
+	require.NotNil(t, patch)
+	assert.Contains(t, changes, ownerChange{path: ".spec.replicas", from: "kubectl", to: FieldManagerClientSideApply})
+
+	clientSide := applyMigrationPatch(t, serverSide, patch)
+	assert.Equal(t, map[string]metav1.ManagedFieldsOperationType{FieldManagerClientSideApply: metav1.ManagedFieldsOperationUpdate}, managersOf(clientSide))
+
+	lastApplied := &unstructured.Unstructured{}
+	require.NoError(t, json.Unmarshal([]byte(clientSide.GetAnnotations()[corev1.LastAppliedConfigAnnotation]), &lastApplied.Object))
+	assert.Equal(t, "ReplicationController", lastApplied.GetKind())
+	assert.Equal(t, "test-rc", lastApplied.GetName())
+	assert.Equal(t, "test", lastApplied.GetNamespace())
+	assert.Equal(t, map[string]string{"name": "test-rc"}, lastApplied.GetLabels())
+	assert.Empty(t, lastApplied.GetManagedFields())
+	assert.Empty(t, lastApplied.GetResourceVersion())
+	replicas, _, _ := unstructured.NestedInt64(lastApplied.Object, "spec", "replicas")
+	assert.Equal(t, int64(1), replicas)
+	containers, _, _ := unstructured.NestedSlice(lastApplied.Object, "spec", "template", "spec", "containers")
+	require.Len(t, containers, 1)
+	assert.Equal(t, "nginx", containers[0].(map[string]interface{})["image"])
+	assert.NotContains(t, lastApplied.Object, "status")
+
+	// migrating again has nothing to do
+	patch, _, err = toClientSide.migrationPatch(clientSide)
+	require.NoError(t, err)
+	assert.Nil(t, patch)
+}
+
+func TestLastAppliedFromOwnedFields(t *testing.T) {
+	obj := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata": map[string]interface{}{
+			"name":      "web",
+			"namespace": "test",
+			"labels":    map[string]interface{}{"app": "web", "pod-template-hash": "abc"},
+		},
+		"spec": map[string]interface{}{
+			"containers": []interface{}{
+				map[string]interface{}{"name": "web", "image": "nginx", "imagePullPolicy": "Always"},
+				map[string]interface{}{"name": "sidecar", "image": "envoy"},
+			},
+			"nodeName": "node-1",
+		},
+	}
+	owned, err := managedFieldsSet(metav1.ManagedFieldsEntry{
+		FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:metadata":{"f:labels":{"f:app":{}}},"f:spec":{"f:containers":{"k:{\"name\":\"web\"}":{".":{},"f:image":{}}}}}`)},
+	})
+	require.NoError(t, err)
+
+	lastApplied, err := lastAppliedFromOwnedFields(obj, owned)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"apiVersion": "v1",
+		"kind": "Pod",
+		"metadata": {"name": "web", "namespace": "test", "labels": {"app": "web"}},
+		"spec": {"containers": [{"name": "web", "image": "nginx"}]}
+	}`, string(lastApplied))
+}
+
+func TestMigrateOwnershipValidate(t *testing.T) {
+	tests := []struct {
+		name         string
+		to           string
+		fieldManager string
+		expectErr    string
+	}{
+		{name: "server-side", to: "server-side", fieldManager: "kubectl"},
+		{name: "client-side", to: "client-side", fieldManager: "kubectl"},
+		{name: "missing to", fieldManager: "kubectl", expectErr: "--to is required, must be one of (server-side, client-side)"},
+		{name: "invalid to", to: "both", fieldManager: "kubectl", expectErr: `invalid --to "both", must be one of (server-side, client-side)`},
+		{name: "client-side field manager", to: "server-side", fieldManager: FieldManagerClientSideApply, expectErr: "--field-manager must not be kubectl-client-side-apply, which is used by client-side apply"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			o := &MigrateOwnershipOptions{To: tc.to, FieldManager: tc.fieldManager}
+			err := o.Validate()
+			if len(tc.expectErr) > 0 {
+				require.EqualError(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
@@ -195,6 +195,12 @@ func TestApplyFlagValidation(t *testing.T) {
 			},
 			expectedErr: "cannot set --all and --selector at the same time",
 		},
+		{
+			args: [][]string{
+				{"prune-confirm", "true"},
+			},
+			expectedErr: "--prune-confirm requires --prune",
+		},
 		{
 			args: [][]string{
 				{"force", "true"},
@@ -266,6 +272,15 @@ func TestApplyFlagValidation(t *testing.T) {
 			enableAlphas: []cmdutil.FeatureGate{cmdutil.ApplySet},
 			expectedErr:  "--prune-allowlist is incompatible with --applyset",
 		},
+		{
+			args: [][]string{
+				{"prune", "true"},
+				{"all", "true"},
+				{"adopt-from-selector", "app=web"},
+			},
+			enableAlphas: []cmdutil.FeatureGate{cmdutil.ApplySet},
+			expectedErr:  "--adopt-from-selector requires --applyset",
+		},
 	}
 
 	for i, test := range tests {
@@ -985,6 +1000,26 @@ func TestApplyPruneObjectsWithAllowlist(t *testing.T) {
 		},
 	}
 
+	// Create a ConfigMap protected from prune.
+	cmProtected := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"kind":       "ConfigMap",
+			"apiVersion": "v1",
+			"metadata": map[string]interface{}{
+				"name":      "test-cm-protected",
+				"namespace": "test",
+				"uid":       "uid-cm-protected",
+			},
+		},
+	}
+	err = setLastAppliedConfigAnnotation(cmProtected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	annotations := cmProtected.GetAnnotations()
+	annotations[PruneProtectAnnotation] = "true"
+	cmProtected.SetAnnotations(annotations)
+
 	testCases := map[string]struct {
 		currentResources        []runtime.Object
 		pruneAllowlist          []string
@@ -1084,6 +1119,14 @@ func TestApplyPruneObjectsWithAllowlist(t *testing.T) {
 				"configmap/test-cm pruned",
 			},
 		},
+		"prune should not delete resources protected from prune": {
+			currentResources:        []runtime.Object{rc, cm, cmProtected},
+			expectedPrunedResources: []string{"test/test-cm"},
+			expectedOutputs: []string{
+				"replicationcontroller/test-rc unchanged",
+				"configmap/test-cm pruned",
+			},
+		},
 	}
 
 	for testCaseName, tc := range testCases {
@@ -185,7 +185,7 @@ func (a ApplySet) Validate(ctx context.Context, client dynamic.Interface) error
 	if !builtinApplySetParentGVRs.Has(a.parentRef.Resource) {
 		// Determine which custom resource types are allowed as ApplySet parents.
 		// Optimization: Since this makes requests, we only do this if they aren't using a default type.
-		permittedCRParents, err := a.getAllowedCustomResourceParents(ctx, client)
+		permittedCRParents, err := getAllowedCustomResourceParents(ctx, client)
 		if err != nil {
 			errors = append(errors, fmt.Errorf("identifying allowed custom resource parent types: %w", err))
 		}
@@ -197,7 +197,7 @@ func (a ApplySet) Validate(ctx context.Context, client dynamic.Interface) error
 	return utilerrors.NewAggregate(errors)
 }
 
-func (a *ApplySet) labelForCustomParentCRDs() *metav1.LabelSelector {
+func labelForCustomParentCRDs() *metav1.LabelSelector {
 	return &metav1.LabelSelector{
 		MatchExpressions: []metav1.LabelSelectorRequirement{{
 			Key:      ApplysetParentCRDLabel,
@@ -206,9 +206,9 @@ func (a *ApplySet) labelForCustomParentCRDs() *metav1.LabelSelector {
 	}
 }
 
-func (a *ApplySet) getAllowedCustomResourceParents(ctx context.Context, client dynamic.Interface) (sets.Set[schema.GroupVersionResource], error) {
+func getAllowedCustomResourceParents(ctx context.Context, client dynamic.Interface) (sets.Set[schema.GroupVersionResource], error) {
 	opts := metav1.ListOptions{
-		LabelSelector: metav1.FormatLabelSelector(a.labelForCustomParentCRDs()),
+		LabelSelector: metav1.FormatLabelSelector(labelForCustomParentCRDs()),
 	}
 	list, err := client.Resource(schema.GroupVersionResource{
 		Group:    "apiextensions.k8s.io",
@@ -285,6 +285,13 @@ func (a *ApplySet) fetchParent() error {
 		return fmt.Errorf("ApplySet parent object %q already exists and is managed by tooling %q instead of %q", a.parentRef, managedBy, a.toolingID.Name)
 	}
 
+	return a.loadParent(labels, annotations)
+}
+
+// loadParent validates the ID label of the live parent object and reads the
+// current set of resources and namespaces from its annotations.
+func (a *ApplySet) loadParent(labels, annotations map[string]string) error {
+	var err error
 	idLabel, hasIDLabel := labels[ApplySetParentIDLabel]
 	if !hasIDLabel {
 		return fmt.Errorf("ApplySet parent object %q exists and does not have required label %s", a.parentRef, ApplySetParentIDLabel)
@@ -429,6 +436,22 @@ func (a *ApplySet) addResource(restMapping *meta.RESTMapping, namespace string)
 	}
 }
 
+// adoptResource registers the given resource and namespace as being part of both the
+// current and the updated set, so that adopted objects are considered for pruning by
+// the current operation.
+func (a *ApplySet) adoptResource(restMapping *meta.RESTMapping, namespace string) {
+	a.addResource(restMapping, namespace)
+	gk := restMapping.GroupVersionKind.GroupKind()
+	if _, found := a.currentResources[gk]; !found {
+		a.currentResources[gk] = &kindInfo{
+			restMapping: restMapping,
+		}
+	}
+	if restMapping.Scope == meta.RESTScopeNamespace && namespace != "" {
+		a.currentNamespaces.Insert(namespace)
+	}
+}
+
 type ApplySetUpdateMode string
 
 var updateToLatestSet ApplySetUpdateMode = "latest"
@@ -573,9 +596,11 @@ func (a *ApplySet) Prune(ctx context.Context, o *ApplyOptions) error {
 		IOStreams: o.IOStreams,
 	}
 
-	if err := a.pruneAll(ctx, o.DynamicClient, o.VisitedUids, opt); err != nil {
+	pruned, err := a.pruneAll(ctx, o.DynamicClient, o.VisitedUids, opt, o.guardPrune)
+	if err != nil {
 		return err
 	}
+	o.prunedObjects = pruned
 
 	if err := a.updateParent(updateToLatestSet, o.DryRunStrategy, o.ValidationDirective); err != nil {
 		return fmt.Errorf("apply and prune succeeded, but ApplySet update failed: %w", err)
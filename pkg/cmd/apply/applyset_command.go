@@ -0,0 +1,542 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/dynamic"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/kubectl/pkg/util/i18n"
+	"k8s.io/kubectl/pkg/util/templates"
+)
+
+var (
+	applySetLong = templates.LongDesc(i18n.T(`
+		Inspect and manage ApplySets.
+
+		An ApplySet is a group of objects applied together with 'apply --applyset', tracked by a
+		parent object. These commands list the ApplySets in a cluster, show the kinds and namespaces
+		an ApplySet tracks, list its live members and delete an ApplySet together with all of its members.`))
+
+	applySetListExample = templates.Examples(i18n.T(`
+		# List the ApplySets in the current namespace
+		kubectl applyset list
+
+		# List the ApplySets in all namespaces
+		kubectl applyset list --all-namespaces`))
+
+	applySetStatusExample = templates.Examples(i18n.T(`
+		# Show the kinds, namespaces and number of members of the ApplySet tracked by secret my-set
+		kubectl applyset status secrets/my-set -n my-ns`))
+
+	applySetMembersExample = templates.Examples(i18n.T(`
+		# List the live members of the ApplySet tracked by secret my-set
+		kubectl applyset members my-set -n my-ns
+
+		# List the live members of an ApplySet with a custom resource parent in YAML
+		kubectl applyset members applysets.company.com/my-set -o yaml`))
+
+	applySetDeleteExample = templates.Examples(i18n.T(`
+		# Delete the ApplySet tracked by secret my-set and all of its members
+		kubectl applyset delete secrets/my-set -n my-ns
+
+		# Show what would be deleted, without deleting anything
+		kubectl applyset delete secrets/my-set -n my-ns --dry-run=client`))
+)
+
+// ApplySetOptions holds the options of the `applyset` subcommands.
+type ApplySetOptions struct {
+	PrintFlags *genericclioptions.PrintFlags
+	ToPrinter  func(string) (printers.ResourcePrinter, error)
+
+	Namespace         string
+	AllNamespaces     bool
+	DryRunStrategy    cmdutil.DryRunStrategy
+	Cascade           string
+	CascadingStrategy metav1.DeletionPropagation
+	GracePeriod       int
+
+	Tooling       ApplySetTooling
+	Mapper        meta.RESTMapper
+	DynamicClient dynamic.Interface
+
+	genericiooptions.IOStreams
+}
+
+// NewApplySetOptions returns the default ApplySetOptions.
+func NewApplySetOptions(streams genericiooptions.IOStreams) *ApplySetOptions {
+	return &ApplySetOptions{
+		PrintFlags:  genericclioptions.NewPrintFlags("").WithTypeSetter(scheme.Scheme).WithDefaultOutput("name"),
+		Cascade:     string(metav1.DeletePropagationBackground),
+		GracePeriod: -1,
+
+		IOStreams: streams,
+	}
+}
+
+// NewCmdApplySet creates the `applyset` command and its subcommands.
+func NewCmdApplySet(baseName string, f cmdutil.Factory, streams genericiooptions.IOStreams) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "applyset SUBCOMMAND",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("[alpha] List, inspect and delete ApplySets"),
+		Long:                  applySetLong,
+		Run:                   cmdutil.DefaultSubCommandRun(streams.ErrOut),
+	}
+
+	cmd.AddCommand(newCmdApplySetList(baseName, f, streams))
+	cmd.AddCommand(newCmdApplySetStatus(baseName, f, streams))
+	cmd.AddCommand(newCmdApplySetMembers(baseName, f, streams))
+	cmd.AddCommand(newCmdApplySetDelete(baseName, f, streams))
+	return cmd
+}
+
+func newCmdApplySetList(baseName string, f cmdutil.Factory, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewApplySetOptions(streams)
+	cmd := &cobra.Command{
+		Use:                   "list",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("List the ApplySets in a namespace"),
+		Example:               applySetListExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 0 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "Unexpected args: %v", args))
+			}
+			cmdutil.CheckErr(o.Complete(f, cmd, baseName))
+			cmdutil.CheckErr(o.RunList(cmd.Context()))
+		},
+	}
+	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, list the ApplySets across all namespaces.")
+	return cmd
+}
+
+func newCmdApplySetStatus(baseName string, f cmdutil.Factory, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewApplySetOptions(streams)
+	cmd := &cobra.Command{
+		Use:                   "status [RESOURCE][.GROUP]/NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Show the kinds, namespaces and members tracked by an ApplySet"),
+		Example:               applySetStatusExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "exactly one ApplySet parent is required"))
+			}
+			cmdutil.CheckErr(o.Complete(f, cmd, baseName))
+			cmdutil.CheckErr(o.RunStatus(cmd.Context(), args[0]))
+		},
+	}
+	return cmd
+}
+
+func newCmdApplySetMembers(baseName string, f cmdutil.Factory, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewApplySetOptions(streams)
+	cmd := &cobra.Command{
+		Use:                   "members [RESOURCE][.GROUP]/NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("List the live members of an ApplySet"),
+		Example:               applySetMembersExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "exactly one ApplySet parent is required"))
+			}
+			cmdutil.CheckErr(o.Complete(f, cmd, baseName))
+			cmdutil.CheckErr(o.RunMembers(cmd.Context(), args[0]))
+		},
+	}
+	o.PrintFlags.AddFlags(cmd)
+	return cmd
+}
+
+func newCmdApplySetDelete(baseName string, f cmdutil.Factory, streams genericiooptions.IOStreams) *cobra.Command {
+	o := NewApplySetOptions(streams)
+	cmd := &cobra.Command{
+		Use:                   "delete [RESOURCE][.GROUP]/NAME",
+		DisableFlagsInUseLine: true,
+		Short:                 i18n.T("Delete an ApplySet and all of its members"),
+		Example:               applySetDeleteExample,
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) != 1 {
+				cmdutil.CheckErr(cmdutil.UsageErrorf(cmd, "exactly one ApplySet parent is required"))
+			}
+			cmdutil.CheckErr(o.Complete(f, cmd, baseName))
+			cmdutil.CheckErr(o.RunDelete(cmd.Context(), args[0]))
+		},
+	}
+	o.PrintFlags.AddFlags(cmd)
+	cmdutil.AddDryRunFlag(cmd)
+	cmd.Flags().StringVar(&o.Cascade, "cascade", o.Cascade, `Must be "background", "orphan", or "foreground". Selects the deletion cascading strategy for the members of the ApplySet. Defaults to background.`)
+	cmd.Flags().IntVar(&o.GracePeriod, "grace-period", o.GracePeriod, "Period of time in seconds given to the members to terminate gracefully. Ignored if negative.")
+	return cmd
+}
+
+// Complete fills in the clients and the options derived from flags.
+func (o *ApplySetOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, baseName string) error {
+	var err error
+	o.Namespace, _, err = f.ToRawKubeConfigLoader().Namespace()
+	if err != nil {
+		return err
+	}
+	o.Mapper, err = f.ToRESTMapper()
+	if err != nil {
+		return err
+	}
+	o.DynamicClient, err = f.DynamicClient()
+	if err != nil {
+		return err
+	}
+	o.Tooling = ApplySetTooling{Name: baseName, Version: ApplySetToolVersion}
+
+	if cmd.Flags().Lookup("dry-run") != nil {
+		o.DryRunStrategy, err = cmdutil.GetDryRunStrategy(cmd)
+		if err != nil {
+			return err
+		}
+	}
+	switch strings.ToLower(o.Cascade) {
+	case "background":
+		o.CascadingStrategy = metav1.DeletePropagationBackground
+	case "foreground":
+		o.CascadingStrategy = metav1.DeletePropagationForeground
+	case "orphan":
+		o.CascadingStrategy = metav1.DeletePropagationOrphan
+	default:
+		return fmt.Errorf(`invalid cascade value (%v). Must be "background", "foreground", or "orphan"`, o.Cascade)
+	}
+
+	o.ToPrinter = func(operation string) (printers.ResourcePrinter, error) {
+		o.PrintFlags.NamePrintFlags.Operation = operation
+		cmdutil.PrintFlagsWithDryRunStrategy(o.PrintFlags, o.DryRunStrategy)
+		return o.PrintFlags.ToPrinter()
+	}
+	return nil
+}
+
+// RunList prints the ApplySet parents found in the namespace, or in all
+// namespaces, together with the tooling managing them and the number of
+// kinds and namespaces they track.
+func (o *ApplySetOptions) RunList(ctx context.Context) error {
+	mappings, err := o.parentMappings(ctx)
+	if err != nil {
+		return err
+	}
+
+	type row struct {
+		namespace, name, tooling string
+		kinds, namespaces        int
+	}
+	var rows []row
+	for _, mapping := range mappings {
+		namespace := ""
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace && !o.AllNamespaces {
+			namespace = o.Namespace
+		}
+		list, err := o.DynamicClient.Resource(mapping.Resource).Namespace(namespace).List(ctx, metav1.ListOptions{
+			LabelSelector: ApplySetParentIDLabel,
+		})
+		if err != nil {
+			return fmt.Errorf("listing %v: %w", mapping.Resource, err)
+		}
+		for i := range list.Items {
+			obj := &list.Items[i]
+			annotations := obj.GetAnnotations()
+			namespaces := parseNamespacesAnnotation(annotations)
+			if len(obj.GetNamespace()) > 0 {
+				namespaces.Insert(obj.GetNamespace())
+			}
+			rows = append(rows, row{
+				namespace:  obj.GetNamespace(),
+				name:       formatApplySetParent(mapping, obj.GetName()),
+				tooling:    valueOrNone(annotations[ApplySetToolingAnnotation]),
+				kinds:      countKindsAnnotation(annotations),
+				namespaces: namespaces.Len(),
+			})
+		}
+	}
+
+	if len(rows) == 0 {
+		if o.AllNamespaces {
+			fmt.Fprintln(o.ErrOut, "No ApplySets found.")
+		} else {
+			fmt.Fprintf(o.ErrOut, "No ApplySets found in %s namespace.\n", o.Namespace)
+		}
+		return nil
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].namespace != rows[j].namespace {
+			return rows[i].namespace < rows[j].namespace
+		}
+		return rows[i].name < rows[j].name
+	})
+
+	w := printers.GetNewTabWriter(o.Out)
+	defer w.Flush()
+	if o.AllNamespaces {
+		fmt.Fprint(w, "NAMESPACE\t")
+	}
+	fmt.Fprintln(w, "NAME\tTOOLING\tKINDS\tNAMESPACES")
+	for _, r := range rows {
+		if o.AllNamespaces {
+			fmt.Fprintf(w, "%s\t", r.namespace)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%d\n", r.name, r.tooling, r.kinds, r.namespaces)
+	}
+	return nil
+}
+
+// RunStatus prints the metadata of an ApplySet parent and the number of
+// live members per kind and namespace.
+func (o *ApplySetOptions) RunStatus(ctx context.Context, parentRef string) error {
+	applySet, parent, err := o.loadApplySet(ctx, parentRef)
+	if err != nil {
+		return err
+	}
+	members, err := applySet.findAllMembers(ctx, o.DynamicClient, sets.New[types.UID]())
+	if err != nil {
+		return err
+	}
+
+	annotations := parent.GetAnnotations()
+	w := printers.GetNewTabWriter(o.Out)
+	fmt.Fprintf(w, "Name:\t%s\n", formatApplySetParent(applySet.parentRef.RESTMapping, parent.GetName()))
+	if applySet.parentRef.IsNamespaced() {
+		fmt.Fprintf(w, "Namespace:\t%s\n", parent.GetNamespace())
+	}
+	fmt.Fprintf(w, "ID:\t%s\n", applySet.ID())
+	fmt.Fprintf(w, "Tooling:\t%s\n", valueOrNone(annotations[ApplySetToolingAnnotation]))
+	fmt.Fprintf(w, "Kinds:\t%s\n", valueOrNone(generateKindsAnnotation(sets.KeySet(applySet.currentResources))))
+	fmt.Fprintf(w, "Namespaces:\t%s\n", valueOrNone(strings.Join(sets.List(applySet.currentNamespaces), ",")))
+
+	type memberKey struct {
+		kind, namespace string
+	}
+	counts := map[memberKey]int{}
+	for _, m := range members {
+		counts[memberKey{kind: m.Mapping.GroupVersionKind.GroupKind().String(), namespace: m.Namespace}]++
+	}
+	keys := make([]memberKey, 0, len(counts))
+	for k := range counts {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind < keys[j].kind
+		}
+		return keys[i].namespace < keys[j].namespace
+	})
+
+	fmt.Fprintf(w, "Members:\t%d\n", len(members))
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	w = printers.GetNewTabWriter(o.Out)
+	fmt.Fprintf(w, "  KIND\tNAMESPACE\tCOUNT\n")
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %s\t%s\t%d\n", k.kind, valueOrNone(k.namespace), counts[k])
+	}
+	return w.Flush()
+}
+
+// RunMembers prints the live members of an ApplySet.
+func (o *ApplySetOptions) RunMembers(ctx context.Context, parentRef string) error {
+	applySet, _, err := o.loadApplySet(ctx, parentRef)
+	if err != nil {
+		return err
+	}
+	members, err := applySet.findAllMembers(ctx, o.DynamicClient, sets.New[types.UID]())
+	if err != nil {
+		return err
+	}
+	if len(members) == 0 {
+		fmt.Fprintf(o.ErrOut, "No members found in ApplySet %s.\n", parentRef)
+		return nil
+	}
+
+	printer, err := o.ToPrinter("")
+	if err != nil {
+		return err
+	}
+	sortPruneObjects(members)
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{"kind": "List", "apiVersion": "v1"}}
+	for _, m := range members {
+		list.Items = append(list.Items, *m.Object.(*unstructured.Unstructured))
+	}
+	if o.PrintFlags.OutputFormat != nil && *o.PrintFlags.OutputFormat == "name" {
+		for i := range list.Items {
+			if err := printer.PrintObj(&list.Items[i], o.Out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return printer.PrintObj(list, o.Out)
+}
+
+// RunDelete deletes all live members of an ApplySet, except the protected
+// ones, and then its parent. ApplySets managed by other tools are refused.
+func (o *ApplySetOptions) RunDelete(ctx context.Context, parentRef string) error {
+	applySet, parent, err := o.loadApplySet(ctx, parentRef)
+	if err != nil {
+		return err
+	}
+	if managedBy := toolingBaseName(parent.GetAnnotations()[ApplySetToolingAnnotation]); managedBy != o.Tooling.Name {
+		return fmt.Errorf("ApplySet parent object %q is managed by tooling %q instead of %q", applySet.parentRef, managedBy, o.Tooling.Name)
+	}
+
+	all, err := applySet.findAllMembers(ctx, o.DynamicClient, sets.New[types.UID]())
+	if err != nil {
+		return err
+	}
+	var members []PruneObject
+	for _, m := range all {
+		if isPruneProtected(m.Object) {
+			fmt.Fprintf(o.ErrOut, "Warning: %s is annotated with %s=true and will not be deleted\n", m.String(), PruneProtectAnnotation)
+			continue
+		}
+		members = append(members, m)
+	}
+	sortPruneObjects(members)
+
+	printer, err := o.ToPrinter("deleted")
+	if err != nil {
+		return err
+	}
+	deleteOptions := &ApplySetDeleteOptions{
+		CascadingStrategy: o.CascadingStrategy,
+		DryRunStrategy:    o.DryRunStrategy,
+		GracePeriod:       o.GracePeriod,
+		Printer:           printer,
+		IOStreams:         o.IOStreams,
+	}
+	if err := applySet.deleteObjects(ctx, o.DynamicClient, members, deleteOptions); err != nil {
+		return err
+	}
+
+	// The parent is deleted last, so that an interrupted deletion can be resumed.
+	if o.DryRunStrategy != cmdutil.DryRunClient {
+		if err := runDelete(ctx, parent.GetNamespace(), parent.GetName(), applySet.parentRef.RESTMapping, o.DynamicClient, o.CascadingStrategy, o.GracePeriod, o.DryRunStrategy == cmdutil.DryRunServer); err != nil {
+			return fmt.Errorf("deleting ApplySet parent %v: %w", applySet.parentRef, err)
+		}
+	}
+	return printer.PrintObj(parent, o.Out)
+}
+
+// loadApplySet fetches the given ApplySet parent and reads the kinds and
+// namespaces it tracks.
+func (o *ApplySetOptions) loadApplySet(ctx context.Context, parentRef string) (*ApplySet, *unstructured.Unstructured, error) {
+	parent, err := ParseApplySetParentRef(parentRef, o.Mapper)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid parent reference %q: %w", parentRef, err)
+	}
+	if parent.IsNamespaced() {
+		parent.Namespace = o.Namespace
+	}
+	applySet := NewApplySet(parent, o.Tooling, o.Mapper, nil)
+
+	obj, err := o.DynamicClient.Resource(parent.Resource).Namespace(parent.Namespace).Get(ctx, parent.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch ApplySet parent object %q: %w", parent, err)
+	}
+	if err := applySet.loadParent(obj.GetLabels(), obj.GetAnnotations()); err != nil {
+		return nil, nil, err
+	}
+	return applySet, obj, nil
+}
+
+// parentMappings returns the mappings of all types which can be used as
+// ApplySet parents: secrets, config maps and the allowed custom resources.
+func (o *ApplySetOptions) parentMappings(ctx context.Context) ([]*meta.RESTMapping, error) {
+	resources := sets.List(builtinApplySetParentGVRs)
+	customResources, err := getAllowedCustomResourceParents(ctx, o.DynamicClient)
+	if err != nil {
+		return nil, fmt.Errorf("identifying allowed custom resource parent types: %w", err)
+	}
+	resources = append(resources, sets.List(customResources)...)
+
+	var mappings []*meta.RESTMapping
+	for _, gvr := range resources {
+		gvk, err := o.Mapper.KindFor(gvr)
+		if err != nil {
+			return nil, err
+		}
+		mapping, err := o.Mapper.RESTMapping(gvk.GroupKind())
+		if err != nil {
+			return nil, err
+		}
+		mappings = append(mappings, mapping)
+	}
+	return mappings, nil
+}
+
+// formatApplySetParent returns the parent reference in the format
+// accepted by --applyset, i.e. RESOURCE[.GROUP]/NAME.
+func formatApplySetParent(mapping *meta.RESTMapping, name string) string {
+	gr := schema.GroupResource{Group: mapping.Resource.Group, Resource: mapping.Resource.Resource}
+	return gr.String() + "/" + name
+}
+
+// countKindsAnnotation returns the number of group kinds listed in the
+// kinds annotation of an ApplySet parent, without resolving them.
+func countKindsAnnotation(annotations map[string]string) int {
+	annotation, ok := annotations[ApplySetGKsAnnotation]
+	if !ok {
+		annotation = annotations[DeprecatedApplySetGRsAnnotation]
+	}
+	if annotation == "" {
+		return 0
+	}
+	return len(strings.Split(annotation, ","))
+}
+
+func sortPruneObjects(objects []PruneObject) {
+	sort.Slice(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if gka, gkb := a.Mapping.GroupVersionKind.GroupKind().String(), b.Mapping.GroupVersionKind.GroupKind().String(); gka != gkb {
+			return gka < gkb
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+}
+
+func valueOrNone(s string) string {
+	if len(s) == 0 {
+		return "<none>"
+	}
+	return s
+}
@@ -0,0 +1,193 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfakeclient "k8s.io/client-go/dynamic/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+func TestApplySetCommand(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	setUpClientsForApplySetWithSSA(t, tf)
+	cmdutil.BehaviorOnFatal(func(s string, i int) {
+		t.Fatalf("unexpected exit %d: %s", i, s)
+	})
+	defer cmdutil.DefaultBehaviorOnFatal()
+
+	ioStreams, _, outbuff, errbuff := genericiooptions.NewTestIOStreams()
+	cmdtesting.WithAlphaEnvs([]cmdutil.FeatureGate{cmdutil.ApplySet}, t, func(t *testing.T) {
+		cmd := NewCmdApply("kubectl", tf, ioStreams)
+		cmd.Flags().Set("filename", filenameRC)
+		cmd.Flags().Set("filename", filenameSVC)
+		cmd.Flags().Set("server-side", "true")
+		cmd.Flags().Set("applyset", "my-set")
+		cmd.Flags().Set("prune", "true")
+		cmd.Run(cmd, []string{})
+	})
+	require.Equal(t, "", errbuff.String())
+
+	t.Run("members", func(t *testing.T) {
+		outbuff.Reset()
+		cmd := newCmdApplySetMembers("kubectl", tf, ioStreams)
+		cmd.Run(cmd, []string{"my-set"})
+		assert.Equal(t, "replicationcontroller/test-rc\nservice/test-service\n", outbuff.String())
+	})
+
+	t.Run("status", func(t *testing.T) {
+		outbuff.Reset()
+		cmd := newCmdApplySetStatus("kubectl", tf, ioStreams)
+		cmd.Run(cmd, []string{"secrets/my-set"})
+		out := outbuff.String()
+		for _, expected := range []string{
+			"Name:         secrets/my-set\n",
+			"Namespace:    test\n",
+			"Kinds:        ReplicationController,Service\n",
+			"Members:      2\n",
+			"  ReplicationController   test        1\n",
+			"  Service                 test        1\n",
+		} {
+			assert.Contains(t, out, expected)
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		outbuff.Reset()
+		cmd := newCmdApplySetDelete("kubectl", tf, ioStreams)
+		cmd.Run(cmd, []string{"my-set"})
+		assert.Equal(t, "replicationcontroller/test-rc deleted\nservice/test-service deleted\nsecret/my-set deleted\n", outbuff.String())
+
+		for _, gvr := range []schema.GroupVersionResource{
+			{Version: "v1", Resource: "replicationcontrollers"},
+			{Version: "v1", Resource: "services"},
+		} {
+			list, err := tf.FakeDynamicClient.Resource(gvr).Namespace("test").List(t.Context(), metav1.ListOptions{})
+			require.NoError(t, err)
+			assert.Empty(t, list.Items, "expected no %v left", gvr.Resource)
+		}
+		_, err := tf.FakeDynamicClient.Tracker().Get(schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, "test", "my-set")
+		assert.True(t, apierrors.IsNotFound(err), "expected the parent to be deleted, got %v", err)
+	})
+}
+
+func TestApplySetCommandDeleteOtherTooling(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	parent := &unstructured.Unstructured{}
+	parent.SetAPIVersion("v1")
+	parent.SetKind("Secret")
+	parent.SetName("my-set")
+	parent.SetNamespace("test")
+	parent.SetLabels(map[string]string{ApplySetParentIDLabel: "applyset-0eFHV8ySqp7XoShsGvyWFQD3s96yqwHmzc4e0HR1dsY-v1"})
+	parent.SetAnnotations(map[string]string{
+		ApplySetToolingAnnotation: "helm/v3",
+		ApplySetGKsAnnotation:     "ReplicationController",
+	})
+	setUpClientsForApplySetWithSSA(t, tf, parent)
+
+	ioStreams, _, _, _ := genericiooptions.NewTestIOStreams()
+	o := NewApplySetOptions(ioStreams)
+	cmd := newCmdApplySetDelete("kubectl", tf, ioStreams)
+	require.NoError(t, o.Complete(tf, cmd, "kubectl"))
+	err := o.RunDelete(t.Context(), "my-set")
+	require.EqualError(t, err, `ApplySet parent object "secrets./my-set" is managed by tooling "helm" instead of "kubectl"`)
+}
+
+func TestApplySetCommandList(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	newParent := func(namespace, name string, annotations map[string]string) runtime.Object {
+		parent := &unstructured.Unstructured{}
+		parent.SetAPIVersion("v1")
+		parent.SetKind("Secret")
+		parent.SetName(name)
+		parent.SetNamespace(namespace)
+		parent.SetLabels(map[string]string{ApplySetParentIDLabel: "applyset-" + name + "-v1"})
+		parent.SetAnnotations(annotations)
+		return parent
+	}
+	notAParent := &unstructured.Unstructured{}
+	notAParent.SetAPIVersion("v1")
+	notAParent.SetKind("Secret")
+	notAParent.SetName("token")
+	notAParent.SetNamespace("test")
+
+	listMapping := map[schema.GroupVersionResource]string{
+		{Version: "v1", Resource: "secrets"}:                                                  "SecretList",
+		{Version: "v1", Resource: "configmaps"}:                                               "ConfigMapList",
+		{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}: "CustomResourceDefinitionList",
+	}
+	tf.FakeDynamicClient = dynamicfakeclient.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), listMapping,
+		newParent("test", "frontend", map[string]string{
+			ApplySetToolingAnnotation:              "kubectl/v1.36.0",
+			ApplySetGKsAnnotation:                  "Deployment.apps,Service",
+			ApplySetAdditionalNamespacesAnnotation: "other",
+		}),
+		newParent("other", "backend", map[string]string{
+			ApplySetToolingAnnotation: "kubectl/v1.36.0",
+			ApplySetGKsAnnotation:     "ConfigMap",
+		}),
+		notAParent,
+	)
+
+	tests := []struct {
+		name          string
+		allNamespaces bool
+		expected      string
+	}{
+		{
+			name: "namespace",
+			expected: `NAME               TOOLING           KINDS   NAMESPACES
+secrets/frontend   kubectl/v1.36.0   2       2
+`,
+		},
+		{
+			name:          "all namespaces",
+			allNamespaces: true,
+			expected: `NAMESPACE   NAME               TOOLING           KINDS   NAMESPACES
+other       secrets/backend    kubectl/v1.36.0   1       1
+test        secrets/frontend   kubectl/v1.36.0   2       2
+`,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ioStreams, _, outbuff, _ := genericiooptions.NewTestIOStreams()
+			o := NewApplySetOptions(ioStreams)
+			o.AllNamespaces = tc.allNamespaces
+			cmd := newCmdApplySetList("kubectl", tf, ioStreams)
+			require.NoError(t, o.Complete(tf, cmd, "kubectl"))
+			require.NoError(t, o.RunList(t.Context()))
+			assert.Equal(t, tc.expected, outbuff.String())
+		})
+	}
+}
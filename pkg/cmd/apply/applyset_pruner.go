@@ -65,7 +65,30 @@ func (p *PruneObject) String() string {
 
 // FindAllObjectsToPrune returns the list of objects that will be pruned.
 // Calling this instead of Prune can be useful for dry-run / diff behaviour.
+// Objects protected with the PruneProtectAnnotation are never pruned and stay
+// in the set.
 func (a *ApplySet) FindAllObjectsToPrune(ctx context.Context, dynamicClient dynamic.Interface, visitedUids sets.Set[types.UID]) ([]PruneObject, error) {
+	members, err := a.findAllMembers(ctx, dynamicClient, visitedUids)
+	if err != nil {
+		return nil, err
+	}
+
+	var pruneObjects []PruneObject
+	for _, member := range members {
+		if isPruneProtected(member.Object) {
+			klog.V(2).Infof("skipping prune of protected object %v", member.String())
+			// the protected object is still a member, so keep its kind and
+			// namespace in the parent for the next operations to find it
+			a.addResource(member.Mapping, member.Namespace)
+			continue
+		}
+		pruneObjects = append(pruneObjects, member)
+	}
+	return pruneObjects, nil
+}
+
+// findAllMembers returns the live objects of the ApplySet whose UID is not in visitedUids.
+func (a *ApplySet) findAllMembers(ctx context.Context, dynamicClient dynamic.Interface, visitedUids sets.Set[types.UID]) ([]PruneObject, error) {
 	type task struct {
 		namespace   string
 		restMapping *meta.RESTMapping
@@ -132,13 +155,16 @@ func (a *ApplySet) FindAllObjectsToPrune(ctx context.Context, dynamicClient dyna
 	return allObjects, nil
 }
 
-func (a *ApplySet) pruneAll(ctx context.Context, dynamicClient dynamic.Interface, visitedUids sets.Set[types.UID], deleteOptions *ApplySetDeleteOptions) error {
+func (a *ApplySet) pruneAll(ctx context.Context, dynamicClient dynamic.Interface, visitedUids sets.Set[types.UID], deleteOptions *ApplySetDeleteOptions, guard func([]PruneObject) error) ([]PruneObject, error) {
 	allObjects, err := a.FindAllObjectsToPrune(ctx, dynamicClient, visitedUids)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	if err := guard(allObjects); err != nil {
+		return nil, err
 	}
 
-	return a.deleteObjects(ctx, dynamicClient, allObjects, deleteOptions)
+	return allObjects, a.deleteObjects(ctx, dynamicClient, allObjects, deleteOptions)
 }
 
 func (a *ApplySet) findObjectsToPrune(ctx context.Context, dynamicClient dynamic.Interface, visitedUids sets.Set[types.UID], namespace string, mapping *meta.RESTMapping) ([]PruneObject, error) {
@@ -0,0 +1,237 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	"k8s.io/kubectl/pkg/polymorphichelpers"
+)
+
+// defaultWaitTimeout is how long --wait-for-ready waits for the objects
+// when no --wait-for-ready-timeout is given.
+const defaultWaitTimeout = 5 * time.Minute
+
+// healthPollInterval is how often the objects are checked by --wait-for-ready.
+var healthPollInterval = 2 * time.Second
+
+// waitTarget is an object --wait-for-ready waits on: an applied object until it is
+// ready, or a pruned object until it is gone.
+type waitTarget struct {
+	mapping   *meta.RESTMapping
+	namespace string
+	name      string
+	uid       types.UID
+	pruned    bool
+
+	done    bool
+	message string
+}
+
+func (t *waitTarget) String() string {
+	gk := t.mapping.GroupVersionKind.GroupKind()
+	s := strings.ToLower(gk.Kind)
+	if len(gk.Group) > 0 {
+		s += "." + gk.Group
+	}
+	return s + "/" + t.name
+}
+
+// waitForObjects waits until every applied object is ready and every pruned
+// object is gone, printing each object as it gets there. Objects which did
+// not get there before the timeout are reported together with their last status.
+func (o *ApplyOptions) waitForObjects(ctx context.Context) error {
+	infos, err := o.GetObjects()
+	if err != nil {
+		return err
+	}
+	var targets []*waitTarget
+	for _, info := range infos {
+		if info.Mapping == nil {
+			continue
+		}
+		targets = append(targets, &waitTarget{mapping: info.Mapping, namespace: info.Namespace, name: info.Name})
+	}
+	for _, p := range o.prunedObjects {
+		target := &waitTarget{mapping: p.Mapping, namespace: p.Namespace, name: p.Name, pruned: true}
+		if obj, err := meta.Accessor(p.Object); err == nil {
+			target.uid = obj.GetUID()
+		}
+		targets = append(targets, target)
+	}
+
+	timeout := o.WaitForReadyTimeout
+	if timeout == 0 {
+		timeout = defaultWaitTimeout
+	}
+
+	var failures []error
+	err = wait.PollUntilContextTimeout(ctx, healthPollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		pending := 0
+		for _, t := range targets {
+			if t.done {
+				continue
+			}
+			done, message, err := checkTarget(ctx, o.DynamicClient, t)
+			if err != nil {
+				// A failed object will not become ready, stop waiting on it.
+				t.done = true
+				failures = append(failures, fmt.Errorf("%s: %w", t, err))
+				continue
+			}
+			t.message = message
+			if !done {
+				pending++
+				continue
+			}
+			t.done = true
+			if !o.shouldPrintObject() {
+				if t.pruned {
+					fmt.Fprintf(o.Out, "%s deleted\n", t)
+				} else {
+					fmt.Fprintf(o.Out, "%s ready\n", t)
+				}
+			}
+		}
+		klog.V(2).Infof("waiting for %d of %d objects", pending, len(targets))
+		return pending == 0, nil
+	})
+	if err != nil && wait.Interrupted(err) {
+		var pending []string
+		for _, t := range targets {
+			if !t.done {
+				pending = append(pending, fmt.Sprintf("%s: %s", t, t.message))
+			}
+		}
+		sort.Strings(pending)
+		failures = append(failures, fmt.Errorf("timed out after %v waiting for %d objects:\n  %s", timeout, len(pending), strings.Join(pending, "\n  ")))
+	} else if err != nil {
+		failures = append(failures, err)
+	}
+	return utilerrors.NewAggregate(failures)
+}
+
+// checkTarget returns whether the target got to the state it is waited for,
+// with a message describing its current status.
+func checkTarget(ctx context.Context, client dynamic.Interface, t *waitTarget) (bool, string, error) {
+	obj, err := client.Resource(t.mapping.Resource).Namespace(t.namespace).Get(ctx, t.name, metav1.GetOptions{})
+	if t.pruned {
+		if errors.IsNotFound(err) {
+			return true, "deleted", nil
+		}
+		if err != nil {
+			return false, err.Error(), nil
+		}
+		if len(t.uid) > 0 && obj.GetUID() != t.uid {
+			// the object was recreated since it was pruned
+			return true, "deleted", nil
+		}
+		return false, "waiting for the object to be deleted", nil
+	}
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return false, "not found", nil
+		}
+		return false, err.Error(), nil
+	}
+	return objectReady(obj)
+}
+
+// objectReady returns whether obj is ready: Deployments, DaemonSets and
+// StatefulSets are ready once rolled out, Jobs once complete and Pods once
+// ready or succeeded. Other objects are ready once their status reflects
+// their latest generation and their Ready condition, if they have one, is True.
+func objectReady(obj *unstructured.Unstructured) (bool, string, error) {
+	gk := obj.GroupVersionKind().GroupKind()
+	if viewer, err := polymorphichelpers.StatusViewerFor(gk); err == nil {
+		message, done, err := viewer.Status(obj, 0)
+		if err != nil && done {
+			// the rollout status of the object cannot be followed
+			return true, "", nil
+		}
+		return done, strings.TrimSpace(message), err
+	}
+
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	switch gk {
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		if status, message := conditionStatus(conditions, "Failed"); status == "True" {
+			return false, "", fmt.Errorf("job failed: %s", message)
+		}
+		if status, _ := conditionStatus(conditions, "Complete"); status == "True" {
+			return true, "complete", nil
+		}
+		return false, "waiting for the job to complete", nil
+	case schema.GroupKind{Kind: "Pod"}:
+		phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+		switch phase {
+		case "Succeeded":
+			return true, "succeeded", nil
+		case "Failed":
+			message, _, _ := unstructured.NestedString(obj.Object, "status", "message")
+			return false, "", fmt.Errorf("pod failed: %s", message)
+		}
+		if status, _ := conditionStatus(conditions, "Ready"); status == "True" {
+			return true, "ready", nil
+		}
+		return false, "waiting for the pod to be ready", nil
+	}
+
+	observedGeneration, found, _ := unstructured.NestedInt64(obj.Object, "status", "observedGeneration")
+	if found && observedGeneration < obj.GetGeneration() {
+		return false, "waiting for the spec update to be observed", nil
+	}
+	status, message := conditionStatus(conditions, "Ready")
+	switch status {
+	case "", "True":
+		return true, "ready", nil
+	default:
+		if len(message) == 0 {
+			message = "waiting for the Ready condition"
+		}
+		return false, message, nil
+	}
+}
+
+// conditionStatus returns the status and message of the condition of the
+// given type, or empty strings if there is no such condition.
+func conditionStatus(conditions []interface{}, conditionType string) (string, string) {
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok || condition["type"] != conditionType {
+			continue
+		}
+		status, _ := condition["status"].(string)
+		message, _ := condition["message"].(string)
+		return status, message
+	}
+	return "", ""
+}
@@ -0,0 +1,227 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	dynamicfakeclient "k8s.io/client-go/dynamic/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+func newHealthTestObject(apiVersion, kind, name string, generation int64, status map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata": map[string]interface{}{
+			"name":       name,
+			"namespace":  "test",
+			"generation": generation,
+		},
+	}}
+	if status != nil {
+		obj.Object["status"] = status
+	}
+	return obj
+}
+
+func healthCondition(conditionType, status string) interface{} {
+	return map[string]interface{}{"type": conditionType, "status": status, "message": conditionType + " is " + status}
+}
+
+func TestObjectReady(t *testing.T) {
+	tests := []struct {
+		name          string
+		obj           *unstructured.Unstructured
+		expectReady   bool
+		expectMessage string
+		expectErr     string
+	}{
+		{
+			name: "deployment rolled out",
+			obj: newHealthTestObject("apps/v1", "Deployment", "web", 2, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"replicas":           int64(1),
+				"updatedReplicas":    int64(1),
+				"availableReplicas":  int64(1),
+			}),
+			expectReady:   true,
+			expectMessage: `deployment "web" successfully rolled out`,
+		},
+		{
+			name:          "deployment not observed",
+			obj:           newHealthTestObject("apps/v1", "Deployment", "web", 2, map[string]interface{}{"observedGeneration": int64(1)}),
+			expectMessage: "Waiting for deployment spec update to be observed...",
+		},
+		{
+			name:          "job complete",
+			obj:           newHealthTestObject("batch/v1", "Job", "migrate", 1, map[string]interface{}{"conditions": []interface{}{healthCondition("Complete", "True")}}),
+			expectReady:   true,
+			expectMessage: "complete",
+		},
+		{
+			name:          "job running",
+			obj:           newHealthTestObject("batch/v1", "Job", "migrate", 1, nil),
+			expectMessage: "waiting for the job to complete",
+		},
+		{
+			name:      "job failed",
+			obj:       newHealthTestObject("batch/v1", "Job", "migrate", 1, map[string]interface{}{"conditions": []interface{}{healthCondition("Failed", "True")}}),
+			expectErr: "job failed: Failed is True",
+		},
+		{
+			name:          "pod ready",
+			obj:           newHealthTestObject("v1", "Pod", "web", 1, map[string]interface{}{"phase": "Running", "conditions": []interface{}{healthCondition("Ready", "True")}}),
+			expectReady:   true,
+			expectMessage: "ready",
+		},
+		{
+			name:          "pod not ready",
+			obj:           newHealthTestObject("v1", "Pod", "web", 1, map[string]interface{}{"phase": "Running", "conditions": []interface{}{healthCondition("Ready", "False")}}),
+			expectMessage: "waiting for the pod to be ready",
+		},
+		{
+			name:          "object without status",
+			obj:           newHealthTestObject("v1", "ConfigMap", "config", 1, nil),
+			expectReady:   true,
+			expectMessage: "ready",
+		},
+		{
+			name:          "custom resource not ready",
+			obj:           newHealthTestObject("company.com/v1", "Widget", "widget", 1, map[string]interface{}{"conditions": []interface{}{healthCondition("Ready", "False")}}),
+			expectMessage: "Ready is False",
+		},
+		{
+			name:          "custom resource with stale status",
+			obj:           newHealthTestObject("company.com/v1", "Widget", "widget", 3, map[string]interface{}{"observedGeneration": int64(2), "conditions": []interface{}{healthCondition("Ready", "True")}}),
+			expectMessage: "waiting for the spec update to be observed",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ready, message, err := objectReady(tc.obj)
+			if len(tc.expectErr) > 0 {
+				require.EqualError(t, err, tc.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectReady, ready)
+			assert.Equal(t, tc.expectMessage, message)
+		})
+	}
+}
+
+func TestWaitForObjects(t *testing.T) {
+	defer func(interval time.Duration) { healthPollInterval = interval }(healthPollInterval)
+	healthPollInterval = 10 * time.Millisecond
+
+	configMapMapping := &meta.RESTMapping{
+		Resource:         schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		Scope:            meta.RESTScopeNamespace,
+	}
+	jobMapping := &meta.RESTMapping{
+		Resource:         schema.GroupVersionResource{Group: "batch", Version: "v1", Resource: "jobs"},
+		GroupVersionKind: schema.GroupVersionKind{Group: "batch", Version: "v1", Kind: "Job"},
+		Scope:            meta.RESTScopeNamespace,
+	}
+	configMap := newHealthTestObject("v1", "ConfigMap", "config", 1, nil)
+	job := newHealthTestObject("batch/v1", "Job", "migrate", 1, nil)
+
+	newOptions := func(objects ...runtime.Object) (*ApplyOptions, *bytes.Buffer) {
+		streams, _, out, _ := genericiooptions.NewTestIOStreams()
+		o := &ApplyOptions{
+			PrintFlags:          genericclioptions.NewPrintFlags("created"),
+			DynamicClient:       dynamicfakeclient.NewSimpleDynamicClient(runtime.NewScheme(), objects...),
+			WaitForReadyTimeout: 200 * time.Millisecond,
+			IOStreams:           streams,
+		}
+		o.SetObjects([]*resource.Info{
+			{Name: "config", Namespace: "test", Mapping: configMapMapping, Object: configMap},
+			{Name: "migrate", Namespace: "test", Mapping: jobMapping, Object: job},
+		})
+		o.prunedObjects = []PruneObject{{Name: "old", Namespace: "test", Mapping: configMapMapping, Object: newHealthTestObject("v1", "ConfigMap", "old", 1, nil)}}
+		return o, out
+	}
+
+	t.Run("ready", func(t *testing.T) {
+		completeJob := newHealthTestObject("batch/v1", "Job", "migrate", 1, map[string]interface{}{"conditions": []interface{}{healthCondition("Complete", "True")}})
+		o, out := newOptions(configMap, completeJob)
+		require.NoError(t, o.waitForObjects(t.Context()))
+		assert.Equal(t, "configmap/config ready\njob.batch/migrate ready\nconfigmap/old deleted\n", out.String())
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		o, _ := newOptions(configMap, job, newHealthTestObject("v1", "ConfigMap", "old", 1, nil))
+		err := o.waitForObjects(t.Context())
+		require.EqualError(t, err, "timed out after 200ms waiting for 2 objects:\n  configmap/old: waiting for the object to be deleted\n  job.batch/migrate: waiting for the job to complete")
+	})
+}
+
+func TestWaitForReadyFlags(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	tests := []struct {
+		name            string
+		flags           map[string]string
+		expectReady     bool
+		expectTimeout   time.Duration
+		expectDeletions bool
+	}{
+		{
+			name:            "wait only waits for deletions",
+			flags:           map[string]string{"wait": "true"},
+			expectTimeout:   defaultWaitTimeout,
+			expectDeletions: true,
+		},
+		{
+			name:          "wait for ready",
+			flags:         map[string]string{"wait-for-ready": "true", "wait-for-ready-timeout": "1m"},
+			expectReady:   true,
+			expectTimeout: time.Minute,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			flags := NewApplyFlags(genericiooptions.NewTestIOStreamsDiscard())
+			flags.AddFlags(cmd)
+			require.NoError(t, cmd.Flags().Set("filename", filenameRC))
+			for name, value := range test.flags {
+				require.NoError(t, cmd.Flags().Set(name, value))
+			}
+			o, err := flags.ToOptions(tf, cmd, "kubectl", []string{})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectReady, o.WaitForReady)
+			assert.Equal(t, test.expectTimeout, o.WaitForReadyTimeout)
+			assert.Equal(t, test.expectDeletions, o.DeleteOptions.WaitForDeletion)
+		})
+	}
+}
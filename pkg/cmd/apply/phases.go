@@ -0,0 +1,243 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/klog/v2"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// applyPhase orders objects so that the objects other objects depend on
+// are applied first.
+type applyPhase int
+
+const (
+	phaseNamespaces applyPhase = iota
+	phaseCustomResourceDefinitions
+	phaseClusterRBAC
+	phaseObjects
+	phaseAdmissionWebhooks
+)
+
+// crdEstablishedTimeout is how long apply waits for the CustomResourceDefinitions
+// it applied to be served before applying the custom resources of their kinds.
+var crdEstablishedTimeout = 60 * time.Second
+
+var (
+	crdGroupKind = schema.GroupKind{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}
+	crdResource  = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+	phaseByGroupKind = map[schema.GroupKind]applyPhase{
+		{Group: "", Kind: "Namespace"}: phaseNamespaces,
+		crdGroupKind:                   phaseCustomResourceDefinitions,
+		{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                       phaseClusterRBAC,
+		{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                phaseClusterRBAC,
+		{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   phaseAdmissionWebhooks,
+		{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: phaseAdmissionWebhooks,
+	}
+)
+
+func phaseOf(info *resource.Info) applyPhase {
+	if info.Mapping == nil {
+		return phaseObjects
+	}
+	if phase, ok := phaseByGroupKind[info.Mapping.GroupVersionKind.GroupKind()]; ok {
+		return phase
+	}
+	return phaseObjects
+}
+
+// sortIntoPhases groups the objects by phase. Objects of the same phase
+// keep their input order.
+func sortIntoPhases(infos []*resource.Info) [][]*resource.Info {
+	sorted := make([]*resource.Info, len(infos))
+	copy(sorted, infos)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return phaseOf(sorted[i]) < phaseOf(sorted[j])
+	})
+
+	var phases [][]*resource.Info
+	for i, info := range sorted {
+		if i == 0 || phaseOf(info) != phaseOf(sorted[i-1]) {
+			phases = append(phases, nil)
+		}
+		phases[len(phases)-1] = append(phases[len(phases)-1], info)
+	}
+	return phases
+}
+
+// applyInPhases applies the objects phase by phase. Once the
+// CustomResourceDefinitions are applied, apply waits for the ones defining
+// kinds it is about to apply to be Established. If some objects could not be
+// mapped because their kinds are defined by those CustomResourceDefinitions,
+// the objects are read again with a new builder and the ones which were
+// missing are applied as well. The errors of reading the objects again
+// replace getErr, so objects whose kinds are still not served are reported.
+func (o *ApplyOptions) applyInPhases(infos []*resource.Info, getErr error) []error {
+	var errs []error
+	missingKinds := meta.IsNoMatchError(getErr)
+
+	applied := sets.New[string]()
+	for _, phase := range sortIntoPhases(infos) {
+		for _, info := range phase {
+			if err := o.applyOneObject(info); err != nil {
+				errs = append(errs, err)
+			}
+			applied.Insert(infoKey(info))
+		}
+		if phaseOf(phase[0]) != phaseCustomResourceDefinitions || o.DryRunStrategy != cmdutil.DryRunNone {
+			continue
+		}
+
+		crds := crdsToWaitFor(phase, infos, missingKinds)
+		if len(crds) == 0 {
+			continue
+		}
+		if err := waitForCRDsEstablished(context.TODO(), o.DynamicClient, crds, crdEstablishedTimeout); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		if !missingKinds {
+			continue
+		}
+
+		// Read the objects again now that their kinds are served, and apply
+		// the ones which could not be mapped before.
+		klog.V(2).Infof("reading objects again after CustomResourceDefinitions were established")
+		meta.MaybeResetRESTMapper(o.Mapper)
+		reloaded, reloadErr := o.readObjects(o.newBuilder())
+		if len(reloaded) == 0 && reloadErr != nil {
+			// nothing could be read again, keep the original error
+			errs = append(errs, reloadErr)
+			break
+		}
+		getErr = reloadErr
+		var added []*resource.Info
+		for _, info := range reloaded {
+			if !applied.Has(infoKey(info)) {
+				added = append(added, info)
+			}
+		}
+		if len(added) == 0 {
+			break
+		}
+		if o.ApplySet != nil {
+			if err := o.ApplySet.AddLabels(added...); err != nil {
+				errs = append(errs, err)
+				break
+			}
+			if err := o.ApplySet.BeforeApply(added, o.DryRunStrategy, o.ValidationDirective); err != nil {
+				errs = append(errs, err)
+				break
+			}
+		}
+		// the objects which are not applied yet are replaced by the ones read again
+		objects := make([]*resource.Info, 0, len(o.objects))
+		for _, info := range o.objects {
+			if applied.Has(infoKey(info)) {
+				objects = append(objects, info)
+			}
+		}
+		o.objects = append(objects, added...)
+		errs = append(errs, o.applyInPhases(added, nil)...)
+		break
+	}
+	if getErr != nil {
+		errs = append([]error{getErr}, errs...)
+	}
+	return errs
+}
+
+// crdsToWaitFor returns the names of the CustomResourceDefinitions that
+// define the kind of one of the objects, or all of them if some objects
+// could not be mapped.
+func crdsToWaitFor(crdInfos, infos []*resource.Info, missingKinds bool) []string {
+	kinds := sets.New[schema.GroupKind]()
+	for _, info := range infos {
+		if info.Mapping != nil {
+			kinds.Insert(info.Mapping.GroupVersionKind.GroupKind())
+		}
+	}
+
+	var names []string
+	for _, info := range crdInfos {
+		obj, err := toUnstructured(info.Object)
+		if err != nil {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(obj, "spec", "group")
+		kind, _, _ := unstructured.NestedString(obj, "spec", "names", "kind")
+		if missingKinds || kinds.Has(schema.GroupKind{Group: group, Kind: kind}) {
+			names = append(names, info.Name)
+		}
+	}
+	return names
+}
+
+// waitForCRDsEstablished waits for the named CustomResourceDefinitions to
+// have the Established condition.
+func waitForCRDsEstablished(ctx context.Context, client dynamic.Interface, names []string, timeout time.Duration) error {
+	for _, name := range names {
+		err := wait.PollUntilContextTimeout(ctx, 250*time.Millisecond, timeout, true, func(ctx context.Context) (bool, error) {
+			crd, err := client.Resource(crdResource).Get(ctx, name, metav1.GetOptions{})
+			if err != nil {
+				return false, err
+			}
+			conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+			for _, c := range conditions {
+				condition, ok := c.(map[string]interface{})
+				if ok && condition["type"] == "Established" && condition["status"] == "True" {
+					return true, nil
+				}
+			}
+			return false, nil
+		})
+		if err != nil {
+			return fmt.Errorf("waiting for CustomResourceDefinition %q to be established: %w", name, err)
+		}
+	}
+	return nil
+}
+
+func toUnstructured(obj runtime.Object) (map[string]interface{}, error) {
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return u.Object, nil
+	}
+	return runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+}
+
+func infoKey(info *resource.Info) string {
+	gk := schema.GroupKind{}
+	if info.Mapping != nil {
+		gk = info.Mapping.GroupVersionKind.GroupKind()
+	}
+	return fmt.Sprintf("%s/%s/%s", gk, info.Namespace, info.Name)
+}
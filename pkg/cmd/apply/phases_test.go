@@ -0,0 +1,255 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	dynamicfakeclient "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/rest/fake"
+	"k8s.io/client-go/restmapper"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+func newPhaseTestInfo(group, kind, name string, object map[string]interface{}) *resource.Info {
+	return &resource.Info{
+		Name:    name,
+		Mapping: &meta.RESTMapping{GroupVersionKind: schema.GroupVersionKind{Group: group, Version: "v1", Kind: kind}},
+		Object:  &unstructured.Unstructured{Object: object},
+	}
+}
+
+func TestSortIntoPhases(t *testing.T) {
+	infos := []*resource.Info{
+		newPhaseTestInfo("admissionregistration.k8s.io", "ValidatingWebhookConfiguration", "webhook", nil),
+		newPhaseTestInfo("apps", "Deployment", "web", nil),
+		newPhaseTestInfo("company.com", "Widget", "widget", nil),
+		newPhaseTestInfo("rbac.authorization.k8s.io", "ClusterRole", "reader", nil),
+		newPhaseTestInfo("apiextensions.k8s.io", "CustomResourceDefinition", "widgets.company.com", nil),
+		newPhaseTestInfo("", "Service", "web", nil),
+		newPhaseTestInfo("", "Namespace", "web", nil),
+	}
+
+	var got [][]string
+	for _, phase := range sortIntoPhases(infos) {
+		var names []string
+		for _, info := range phase {
+			names = append(names, info.Mapping.GroupVersionKind.Kind+"/"+info.Name)
+		}
+		got = append(got, names)
+	}
+	assert.Equal(t, [][]string{
+		{"Namespace/web"},
+		{"CustomResourceDefinition/widgets.company.com"},
+		{"ClusterRole/reader"},
+		{"Deployment/web", "Widget/widget", "Service/web"},
+		{"ValidatingWebhookConfiguration/webhook"},
+	}, got)
+}
+
+func TestCRDsToWaitFor(t *testing.T) {
+	crd := func(name, group, kind string) *resource.Info {
+		return newPhaseTestInfo("apiextensions.k8s.io", "CustomResourceDefinition", name, map[string]interface{}{
+			"spec": map[string]interface{}{
+				"group": group,
+				"names": map[string]interface{}{"kind": kind},
+			},
+		})
+	}
+	crds := []*resource.Info{
+		crd("widgets.company.com", "company.com", "Widget"),
+		crd("gadgets.company.com", "company.com", "Gadget"),
+	}
+	infos := append([]*resource.Info{newPhaseTestInfo("company.com", "Widget", "widget", nil)}, crds...)
+
+	assert.Equal(t, []string{"widgets.company.com"}, crdsToWaitFor(crds, infos, false))
+	assert.Equal(t, []string{"widgets.company.com", "gadgets.company.com"}, crdsToWaitFor(crds, infos, true))
+}
+
+func TestWaitForCRDsEstablished(t *testing.T) {
+	crd := func(name string, established string) runtime.Object {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata":   map[string]interface{}{"name": name},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "NamesAccepted", "status": "True"},
+					map[string]interface{}{"type": "Established", "status": established},
+				},
+			},
+		}}
+	}
+	client := dynamicfakeclient.NewSimpleDynamicClient(runtime.NewScheme(),
+		crd("widgets.company.com", "True"),
+		crd("gadgets.company.com", "False"),
+	)
+
+	require.NoError(t, waitForCRDsEstablished(t.Context(), client, []string{"widgets.company.com"}, time.Second))
+	err := waitForCRDsEstablished(t.Context(), client, []string{"widgets.company.com", "gadgets.company.com"}, 100*time.Millisecond)
+	require.ErrorContains(t, err, `waiting for CustomResourceDefinition "gadgets.company.com" to be established`)
+}
+
+// resettableMapper serves the kinds of the CustomResourceDefinitions
+// applied by a test once it is reset, like a discovery based mapper.
+type resettableMapper struct {
+	*meta.DefaultRESTMapper
+	served []schema.GroupVersionKind
+}
+
+func (m *resettableMapper) Reset() {
+	for _, gvk := range m.served {
+		m.Add(gvk, meta.RESTScopeNamespace)
+	}
+}
+
+func TestApplyInPhasesReadsObjectsAgain(t *testing.T) {
+	const manifest = `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.company.com
+spec:
+  group: company.com
+  names:
+    kind: Widget
+    plural: widgets
+  scope: Namespaced
+---
+apiVersion: company.com/v1
+kind: Widget
+metadata:
+  name: widget
+---
+apiVersion: company.com/v1
+kind: Gadget
+metadata:
+  name: gadget
+`
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	mapper := &resettableMapper{
+		DefaultRESTMapper: meta.NewDefaultRESTMapper(nil),
+		served:            []schema.GroupVersionKind{{Group: "company.com", Version: "v1", Kind: "Widget"}},
+	}
+	mapper.Add(crdGroupKind.WithVersion("v1"), meta.RESTScopeRoot)
+
+	created := map[string]int{}
+	client := &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			switch req.Method {
+			case "GET":
+				return &http.Response{StatusCode: http.StatusNotFound, Header: cmdtesting.DefaultHeader(), Body: io.NopCloser(bytes.NewReader(nil))}, nil
+			case "POST":
+				created[req.URL.Path]++
+				return &http.Response{StatusCode: http.StatusCreated, Header: cmdtesting.DefaultHeader(), Body: req.Body}, nil
+			default:
+				t.Fatalf("unexpected request: %#v\n%#v", req.URL, req)
+				return nil, nil
+			}
+		}),
+	}
+	newBuilder := func() *resource.Builder {
+		return resource.NewFakeBuilder(
+			func(schema.GroupVersion) (resource.RESTClient, error) { return client, nil },
+			func() (meta.RESTMapper, error) { return mapper, nil },
+			func() (restmapper.CategoryExpander, error) { return resource.FakeCategoryExpander, nil },
+		)
+	}
+
+	streams, in, _, _ := genericiooptions.NewTestIOStreams()
+	in.WriteString(manifest)
+	cmd := &cobra.Command{}
+	flags := NewApplyFlags(streams)
+	flags.AddFlags(cmd)
+	require.NoError(t, cmd.Flags().Set("filename", "-"))
+	o, err := flags.ToOptions(tf, cmd, "kubectl", []string{})
+	require.NoError(t, err)
+	require.True(t, o.Ordered, "expected the objects to be applied in phases by default")
+	o.Mapper = mapper
+	o.Builder = newBuilder()
+	o.newBuilder = newBuilder
+	o.DynamicClient = dynamicfakeclient.NewSimpleDynamicClient(runtime.NewScheme(),
+		&unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apiextensions.k8s.io/v1",
+			"kind":       "CustomResourceDefinition",
+			"metadata":   map[string]interface{}{"name": "widgets.company.com"},
+			"status": map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Established", "status": "True"},
+				},
+			},
+		}},
+	)
+	require.NoError(t, o.Validate())
+
+	// the gadget kind is never served, which must fail apply
+	err = o.Run()
+	require.ErrorContains(t, err, `"gadget"`)
+
+	// stdin is read again, and every object is created exactly once
+	assert.Equal(t, map[string]int{
+		"/customresourcedefinitions": 1,
+		"/namespaces/test/widgets":   1,
+	}, created)
+	var names []string
+	for _, info := range o.objects {
+		names = append(names, info.Name)
+	}
+	assert.Equal(t, []string{"widgets.company.com", "widget"}, names)
+}
+
+func TestOrderedFlag(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	for _, test := range []struct {
+		value    string
+		expected bool
+	}{
+		{expected: true},
+		{value: "true", expected: true},
+		{value: "false", expected: false},
+	} {
+		t.Run("ordered="+test.value, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			flags := NewApplyFlags(genericiooptions.NewTestIOStreamsDiscard())
+			flags.AddFlags(cmd)
+			require.NoError(t, cmd.Flags().Set("filename", filenameRC))
+			if len(test.value) > 0 {
+				require.NoError(t, cmd.Flags().Set("ordered", test.value))
+			}
+			o, err := flags.ToOptions(tf, cmd, "kubectl", []string{})
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, o.Ordered)
+		})
+	}
+}
@@ -20,18 +20,32 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/client-go/dynamic"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/prune"
+	"k8s.io/kubectl/pkg/util/term"
 )
 
+// PruneProtectAnnotation protects an object from being pruned by apply
+// when set to "true", both with --applyset and with the legacy pruner.
+const PruneProtectAnnotation = "kubectl.kubernetes.io/prune-protect"
+
+// isTerminalIn returns whether in is a terminal, in which case prune asks
+// for confirmation unless --prune-confirm=false is given.
+var isTerminalIn = func(in io.Reader) bool {
+	return term.TTY{In: in}.IsTerminalIn()
+}
+
 type pruner struct {
 	mapper        meta.RESTMapper
 	dynamicClient dynamic.Interface
@@ -47,6 +61,9 @@ type pruner struct {
 
 	toPrinter func(string) (printers.ResourcePrinter, error)
 
+	// pruned records the objects deleted by the pruner.
+	pruned []PruneObject
+
 	out io.Writer
 }
 
@@ -76,24 +93,49 @@ func (p *pruner) pruneAll(o *ApplyOptions) error {
 		return fmt.Errorf("error retrieving RESTMappings to prune: %v", err)
 	}
 
+	var pruneObjects []PruneObject
 	for n := range p.visitedNamespaces {
 		for _, m := range namespacedRESTMappings {
-			if err := p.prune(n, m); err != nil {
+			objs, err := p.findObjectsToPrune(n, m)
+			if err != nil {
 				return fmt.Errorf("error pruning namespaced object %v: %v", m.GroupVersionKind, err)
 			}
+			pruneObjects = append(pruneObjects, objs...)
 		}
 	}
 
 	for _, m := range nonNamespacedRESTMappings {
-		if err := p.prune(metav1.NamespaceNone, m); err != nil {
+		objs, err := p.findObjectsToPrune(metav1.NamespaceNone, m)
+		if err != nil {
 			return fmt.Errorf("error pruning nonNamespaced object %v: %v", m.GroupVersionKind, err)
 		}
+		pruneObjects = append(pruneObjects, objs...)
 	}
 
+	if err := o.guardPrune(pruneObjects); err != nil {
+		return err
+	}
+
+	for _, obj := range pruneObjects {
+		if p.dryRunStrategy != cmdutil.DryRunClient {
+			if err := p.delete(obj.Namespace, obj.Name, obj.Mapping); err != nil {
+				return fmt.Errorf("error pruning %v: %v", obj.String(), err)
+			}
+		}
+		p.pruned = append(p.pruned, obj)
+
+		printer, err := p.toPrinter("pruned")
+		if err != nil {
+			return err
+		}
+		printer.PrintObj(obj.Object, p.out)
+	}
 	return nil
 }
 
-func (p *pruner) prune(namespace string, mapping *meta.RESTMapping) error {
+// findObjectsToPrune lists the objects of the given mapping created with
+// apply which were not applied in this run and are not protected.
+func (p *pruner) findObjectsToPrune(namespace string, mapping *meta.RESTMapping) ([]PruneObject, error) {
 	objList, err := p.dynamicClient.Resource(mapping.Resource).
 		Namespace(namespace).
 		List(context.TODO(), metav1.ListOptions{
@@ -101,18 +143,19 @@ func (p *pruner) prune(namespace string, mapping *meta.RESTMapping) error {
 			FieldSelector: p.fieldSelector,
 		})
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	objs, err := meta.ExtractList(objList)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
+	var pruneObjects []PruneObject
 	for _, obj := range objs {
 		metadata, err := meta.Accessor(obj)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		annots := metadata.GetAnnotations()
 		if _, ok := annots[corev1.LastAppliedConfigAnnotation]; !ok {
@@ -123,22 +166,56 @@ func (p *pruner) prune(namespace string, mapping *meta.RESTMapping) error {
 		if p.visitedUids.Has(uid) {
 			continue
 		}
-		name := metadata.GetName()
-		if p.dryRunStrategy != cmdutil.DryRunClient {
-			if err := p.delete(namespace, name, mapping); err != nil {
-				return err
-			}
+		if isPruneProtected(obj) {
+			continue
 		}
+		pruneObjects = append(pruneObjects, PruneObject{Name: metadata.GetName(), Namespace: namespace, Mapping: mapping, Object: obj})
+	}
+	return pruneObjects, nil
+}
 
-		printer, err := p.toPrinter("pruned")
-		if err != nil {
-			return err
-		}
-		printer.PrintObj(obj, p.out)
+// isPruneProtected returns whether obj is protected from prune with the
+// PruneProtectAnnotation.
+func isPruneProtected(obj runtime.Object) bool {
+	metadata, err := meta.Accessor(obj)
+	if err != nil {
+		return false
+	}
+	return metadata.GetAnnotations()[PruneProtectAnnotation] == "true"
+}
+
+// guardPrune refuses to prune more objects than allowed by
+// --prune-max-deletions and, when stdin is a terminal or with
+// --prune-confirm, asks for confirmation before objects are deleted.
+func (o *ApplyOptions) guardPrune(objects []PruneObject) error {
+	if len(objects) == 0 {
+		return nil
+	}
+	if o.PruneMaxDeletions >= 0 && len(objects) > o.PruneMaxDeletions {
+		return fmt.Errorf("refusing to prune %d objects, more than allowed by --prune-max-deletions=%d:\n%s", len(objects), o.PruneMaxDeletions, formatPruneObjects(objects))
+	}
+	if !o.ConfirmPrune || o.DryRunStrategy != cmdutil.DryRunNone {
+		return nil
+	}
+
+	fmt.Fprintf(o.Out, i18n.T("Prune is about to delete the following %d resource(s):\n"), len(objects)) //nolint:errcheck
+	fmt.Fprintln(o.Out, formatPruneObjects(objects))                                                     //nolint:errcheck
+	fmt.Fprint(o.Out, i18n.T("Do you want to continue?")+" (y/N): ")                                     //nolint:errcheck
+	var input string
+	if _, err := fmt.Fscanln(o.In, &input); err != nil || !strings.EqualFold(input, "y") {
+		return fmt.Errorf("prune aborted")
 	}
 	return nil
 }
 
+func formatPruneObjects(objects []PruneObject) string {
+	lines := make([]string, 0, len(objects))
+	for i := range objects {
+		lines = append(lines, objects[i].String())
+	}
+	return strings.Join(lines, "\n")
+}
+
 func (p *pruner) delete(namespace, name string, mapping *meta.RESTMapping) error {
 	ctx := context.TODO()
 	return runDelete(ctx, namespace, name, mapping, p.dynamicClient, p.cascadingStrategy, p.gracePeriod, p.dryRunStrategy == cmdutil.DryRunServer)
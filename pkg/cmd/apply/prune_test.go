@@ -0,0 +1,195 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package apply
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	dynamicfakeclient "k8s.io/client-go/dynamic/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+func TestGuardPrune(t *testing.T) {
+	mapping := &meta.RESTMapping{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}}
+	objects := []PruneObject{
+		{Name: "a", Namespace: "test", Mapping: mapping, Object: &unstructured.Unstructured{}},
+		{Name: "b", Namespace: "test", Mapping: mapping, Object: &unstructured.Unstructured{}},
+	}
+
+	tests := []struct {
+		name         string
+		maxDeletions int
+		confirm      bool
+		dryRun       cmdutil.DryRunStrategy
+		input        string
+		expectErr    string
+		expectOut    string
+	}{
+		{
+			name:         "no limit",
+			maxDeletions: -1,
+		},
+		{
+			name:         "within limit",
+			maxDeletions: 2,
+		},
+		{
+			name:         "over limit",
+			maxDeletions: 1,
+			expectErr:    "refusing to prune 2 objects, more than allowed by --prune-max-deletions=1:\nConfigMap test/a\nConfigMap test/b",
+		},
+		{
+			name:         "confirmed",
+			maxDeletions: -1,
+			confirm:      true,
+			input:        "y\n",
+			expectOut:    "Prune is about to delete the following 2 resource(s):\nConfigMap test/a\nConfigMap test/b\nDo you want to continue? (y/N): ",
+		},
+		{
+			name:         "declined",
+			maxDeletions: -1,
+			confirm:      true,
+			input:        "n\n",
+			expectErr:    "prune aborted",
+			expectOut:    "Prune is about to delete the following 2 resource(s):\nConfigMap test/a\nConfigMap test/b\nDo you want to continue? (y/N): ",
+		},
+		{
+			name:         "no confirmation on dry run",
+			maxDeletions: -1,
+			confirm:      true,
+			dryRun:       cmdutil.DryRunServer,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			streams, in, out, _ := genericiooptions.NewTestIOStreams()
+			in.WriteString(tc.input)
+			o := &ApplyOptions{
+				PruneMaxDeletions: tc.maxDeletions,
+				ConfirmPrune:      tc.confirm,
+				DryRunStrategy:    tc.dryRun,
+				IOStreams:         streams,
+			}
+			err := o.guardPrune(objects)
+			if len(tc.expectErr) > 0 {
+				require.EqualError(t, err, tc.expectErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expectOut, out.String())
+		})
+	}
+}
+
+func TestIsPruneProtected(t *testing.T) {
+	obj := &unstructured.Unstructured{}
+	assert.False(t, isPruneProtected(obj))
+	obj.SetAnnotations(map[string]string{PruneProtectAnnotation: "false"})
+	assert.False(t, isPruneProtected(obj))
+	obj.SetAnnotations(map[string]string{PruneProtectAnnotation: "true"})
+	assert.True(t, isPruneProtected(obj))
+}
+
+func TestApplySetKeepsPruneProtectedMembers(t *testing.T) {
+	secrets := &meta.RESTMapping{
+		Resource:         schema.GroupVersionResource{Version: "v1", Resource: "secrets"},
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Secret"},
+		Scope:            meta.RESTScopeNamespace,
+	}
+	configMaps := &meta.RESTMapping{
+		Resource:         schema.GroupVersionResource{Version: "v1", Resource: "configmaps"},
+		GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"},
+		Scope:            meta.RESTScopeNamespace,
+	}
+	applySet := NewApplySet(&ApplySetParentRef{Name: "my-set", Namespace: "test", RESTMapping: secrets}, ApplySetTooling{Name: "kubectl"}, nil, nil)
+	// the parent of the last apply lists the ConfigMaps of the namespace other
+	applySet.currentResources[configMaps.GroupVersionKind.GroupKind()] = &kindInfo{restMapping: configMaps}
+	applySet.currentNamespaces.Insert("other")
+
+	protected := &unstructured.Unstructured{}
+	protected.SetAPIVersion("v1")
+	protected.SetKind("ConfigMap")
+	protected.SetName("protected")
+	protected.SetNamespace("other")
+	protected.SetLabels(applySet.LabelsForMember())
+	protected.SetAnnotations(map[string]string{PruneProtectAnnotation: "true"})
+	client := dynamicfakeclient.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{configMaps.Resource: "ConfigMapList"}, protected)
+
+	pruned, err := applySet.FindAllObjectsToPrune(t.Context(), client, sets.New[types.UID]())
+	require.NoError(t, err)
+	assert.Empty(t, pruned)
+
+	annotations := applySet.buildParentPatch(updateToLatestSet).Annotations
+	assert.Equal(t, "ConfigMap", annotations[ApplySetGKsAnnotation])
+	assert.Equal(t, "other", annotations[ApplySetAdditionalNamespacesAnnotation])
+}
+
+func TestPruneConfirmFlag(t *testing.T) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+
+	tests := []struct {
+		name     string
+		terminal bool
+		confirm  string
+		expected bool
+	}{
+		{name: "not a terminal", expected: false},
+		{name: "terminal", terminal: true, expected: true},
+		{name: "prune-confirm", confirm: "true", expected: true},
+		{name: "terminal with prune-confirm=false", terminal: true, confirm: "false", expected: false},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			defer func(isTerminal func(io.Reader) bool) { isTerminalIn = isTerminal }(isTerminalIn)
+			isTerminalIn = func(io.Reader) bool { return tc.terminal }
+
+			cmd := &cobra.Command{}
+			flags := NewApplyFlags(genericiooptions.NewTestIOStreamsDiscard())
+			flags.AddFlags(cmd)
+			require.NoError(t, cmd.Flags().Set("filename", filenameRC))
+			require.NoError(t, cmd.Flags().Set("prune", "true"))
+			require.NoError(t, cmd.Flags().Set("all", "true"))
+			if len(tc.confirm) > 0 {
+				require.NoError(t, cmd.Flags().Set("prune-confirm", tc.confirm))
+			}
+			o, err := flags.ToOptions(tf, cmd, "kubectl", []string{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, o.ConfirmPrune)
+		})
+	}
+
+	// without confirmation, prune does not read from stdin, so it does not
+	// block or abort when run non-interactively
+	mapping := &meta.RESTMapping{GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "ConfigMap"}}
+	streams, _, out, _ := genericiooptions.NewTestIOStreams()
+	o := &ApplyOptions{PruneMaxDeletions: -1, IOStreams: streams}
+	require.NoError(t, o.guardPrune([]PruneObject{{Name: "a", Namespace: "test", Mapping: mapping, Object: &unstructured.Unstructured{}}}))
+	assert.Empty(t, out.String())
+}
@@ -358,6 +358,9 @@ func NewKubectlCommand(o KubectlOptions) *cobra.Command {
 	if !cmdutil.KubeRC.IsDisabled() {
 		cmds.AddCommand(kuberccmd.NewCmdKubeRC(o.IOStreams))
 	}
+	if cmdutil.ApplySet.IsEnabled() {
+		cmds.AddCommand(apply.NewCmdApplySet("kubectl", f, o.IOStreams))
+	}
 
 	// Stop warning about normalization of flags. That makes it possible to
 	// add the klog flags later.
@@ -0,0 +1,238 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/dynamic"
+)
+
+// ContextObject is an implementation of the Object interface for the live
+// versions of an object in two contexts: the live version comes from the
+// first context and the merged version from the second one. Either may be
+// nil when the object only exists in one of the clusters.
+type ContextObject struct {
+	name string
+	a    runtime.Object
+	b    runtime.Object
+}
+
+var _ Object = &ContextObject{}
+
+// Live returns the version of the object in the first context.
+func (obj *ContextObject) Live() runtime.Object {
+	return obj.a
+}
+
+// Merged returns the version of the object in the second context.
+func (obj *ContextObject) Merged() (runtime.Object, error) {
+	return obj.b, nil
+}
+
+func (obj *ContextObject) Name() string {
+	return obj.name
+}
+
+// contextObjects pairs the objects found in two contexts by their group,
+// kind, namespace and name, keeping the order they were found in.
+type contextObjects struct {
+	keys    []string
+	objects map[string]*ContextObject
+}
+
+func newContextObjects() *contextObjects {
+	return &contextObjects{objects: map[string]*ContextObject{}}
+}
+
+// add records the object found in the first context if second is false,
+// and in the second one otherwise.
+func (c *contextObjects) add(info *resource.Info, obj runtime.Object, second bool) {
+	gk := info.Mapping.GroupVersionKind.GroupKind()
+	key := fmt.Sprintf("%s/%s/%s", gk, info.Namespace, info.Name)
+	o, found := c.objects[key]
+	if !found {
+		o = &ContextObject{name: InfoObject{Info: info}.Name()}
+		c.objects[key] = o
+		c.keys = append(c.keys, key)
+	}
+	if second {
+		o.b = obj
+	} else {
+		o.a = obj
+	}
+}
+
+// visitContexts finds the objects in both contexts, either the objects of
+// the files or the live objects of the given types, and passes them to diff.
+func (o *DiffOptions) visitContexts(diff func(obj Object) error) error {
+	var objects *contextObjects
+	var err error
+	if len(o.args) > 0 {
+		objects, err = o.listContextObjects()
+	} else {
+		objects, err = o.getContextObjects()
+	}
+	if err != nil {
+		return err
+	}
+
+	for _, key := range objects.keys {
+		obj := objects.objects[key]
+		if obj.a == nil && obj.b == nil {
+			// The object of the files exists in neither cluster.
+			continue
+		}
+		if err := diff(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listContextObjects lists the live objects of the given types in both contexts.
+func (o *DiffOptions) listContextObjects() (*contextObjects, error) {
+	objects := newContextObjects()
+	for i, f := range o.contextFactories {
+		r := f.NewBuilder().
+			Unstructured().
+			NamespaceParam(o.CmdNamespace).DefaultNamespace().
+			LabelSelectorParam(o.Selector).
+			ResourceTypeOrNameArgs(true, o.args...).
+			Flatten().
+			Do()
+		if err := r.Err(); err != nil {
+			return nil, err
+		}
+		err := r.Visit(func(info *resource.Info, err error) error {
+			if err != nil {
+				return err
+			}
+			objects.add(info, info.Object, i == 1)
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("context %q: %w", o.contexts()[i], err)
+		}
+	}
+	return objects, nil
+}
+
+// getContextObjects gets the live versions of the objects of the files in
+// both contexts. The files are read only once, as stdin cannot be read again.
+func (o *DiffOptions) getContextObjects() (*contextObjects, error) {
+	infos, err := o.contextFactories[0].NewBuilder().
+		Unstructured().
+		NamespaceParam(o.CmdNamespace).DefaultNamespace().
+		FilenameParam(o.EnforceNamespace, &o.FilenameOptions).
+		LabelSelectorParam(o.Selector).
+		Flatten().
+		Do().
+		Infos()
+	if err != nil {
+		return nil, err
+	}
+
+	objects := newContextObjects()
+	for i, f := range o.contextFactories {
+		mapper, err := f.ToRESTMapper()
+		if err != nil {
+			return nil, err
+		}
+		client, err := f.DynamicClient()
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			obj, err := getInContext(mapper, client, info)
+			if err != nil {
+				return nil, fmt.Errorf("context %q: %w", o.contexts()[i], err)
+			}
+			objects.add(info, obj, i == 1)
+		}
+	}
+	return objects, nil
+}
+
+// getInContext returns the live version of the object of info in the
+// cluster of mapper and client, or nil if it does not exist there.
+func getInContext(mapper meta.RESTMapper, client dynamic.Interface, info *resource.Info) (runtime.Object, error) {
+	gvk := info.Mapping.GroupVersionKind
+	mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// The kind of the object is not served by the cluster.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	namespace := info.Namespace
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		namespace = ""
+	}
+	obj, err := client.Resource(mapping.Resource).Namespace(namespace).Get(context.TODO(), info.Name, metav1.GetOptions{})
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+func (o *DiffOptions) contexts() []string {
+	return []string{o.ContextA, o.ContextB}
+}
+
+// invalidDirChars matches the characters of a context name which cannot be
+// used in the name of a temporary directory.
+var invalidDirChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// newContextDiffer creates a Differ whose directories are named after the
+// contexts, so that the diff program tells which cluster each side is from.
+func newContextDiffer(contextA, contextB string) (*Differ, error) {
+	differ := Differ{}
+	var err error
+	differ.From, err = newContextDiffVersion("LIVE", contextA)
+	if err != nil {
+		return nil, err
+	}
+	differ.To, err = newContextDiffVersion("MERGED", contextB)
+	if err != nil {
+		differ.From.Dir.Delete()
+		return nil, err
+	}
+	return &differ, nil
+}
+
+func newContextDiffVersion(name, context string) (*DiffVersion, error) {
+	dir, err := CreateDirectory(invalidDirChars.ReplaceAllString(context, "_"))
+	if err != nil {
+		return nil, err
+	}
+	return &DiffVersion{
+		Dir:  dir,
+		Name: name,
+	}, nil
+}
@@ -0,0 +1,272 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	fakedynamic "k8s.io/client-go/dynamic/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+func newInfo(group, version, kind, namespace, name string) *resource.Info {
+	return &resource.Info{
+		Namespace: namespace,
+		Name:      name,
+		Mapping: &meta.RESTMapping{
+			GroupVersionKind: schema.GroupVersionKind{Group: group, Version: version, Kind: kind},
+		},
+	}
+}
+
+func TestContextObjects(t *testing.T) {
+	web := &unstructured.Unstructured{Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "web"}}}
+	db := &unstructured.Unstructured{Object: map[string]interface{}{"metadata": map[string]interface{}{"name": "db"}}}
+
+	objects := newContextObjects()
+	objects.add(newInfo("apps", "v1", "Deployment", "test", "web"), web, false)
+	objects.add(newInfo("apps", "v1", "Deployment", "test", "db"), db, false)
+	objects.add(newInfo("apps", "v1", "Deployment", "test", "web"), web, true)
+	objects.add(newInfo("", "v1", "Service", "test", "web"), web, true)
+
+	var names []string
+	for _, key := range objects.keys {
+		names = append(names, objects.objects[key].Name())
+	}
+	expected := []string{"apps.v1.Deployment.test.web", "apps.v1.Deployment.test.db", "v1.Service.test.web"}
+	if diff := cmp.Diff(expected, names); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+
+	deployment := objects.objects["Deployment.apps/test/web"]
+	if deployment.Live() != web {
+		t.Errorf("expected the deployment of the first context")
+	}
+	if merged, _ := deployment.Merged(); merged != web {
+		t.Errorf("expected the deployment of the second context")
+	}
+	if merged, _ := objects.objects["Deployment.apps/test/db"].Merged(); merged != nil {
+		t.Errorf("expected no deployment in the second context, got %v", merged)
+	}
+	if live := objects.objects["Service/test/web"].Live(); live != nil {
+		t.Errorf("expected no service in the first context, got %v", live)
+	}
+}
+
+func newContextConfigMap(name, value string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": name, "namespace": "test"},
+		"data":       map[string]interface{}{"value": value},
+	}}
+}
+
+func TestVisitContextsReadsFilesOnce(t *testing.T) {
+	manifest := filepath.Join(t.TempDir(), "manifest.yaml")
+	err := os.WriteFile(manifest, []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: db
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cache
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: missing
+`), 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newContextFactory := func(objects ...runtime.Object) *cmdtesting.TestFactory {
+		tf := cmdtesting.NewTestFactory().WithNamespace("test")
+		tf.FakeDynamicClient = fakedynamic.NewSimpleDynamicClient(scheme.Scheme, objects...)
+		return tf
+	}
+	staging := newContextFactory(newContextConfigMap("web", "a"), newContextConfigMap("db", "a"))
+	defer staging.Cleanup()
+	prod := newContextFactory(newContextConfigMap("web", "b"), newContextConfigMap("cache", "b"))
+	defer prod.Cleanup()
+
+	o := &DiffOptions{
+		ContextA:         "staging",
+		ContextB:         "prod",
+		CmdNamespace:     "test",
+		FilenameOptions:  resource.FilenameOptions{Filenames: []string{manifest}},
+		contextFactories: []cmdutil.Factory{staging, prod},
+	}
+
+	var found []string
+	err = o.visitContexts(func(obj Object) error {
+		merged, _ := obj.Merged()
+		found = append(found, obj.Name()+" "+contextValue(obj.Live())+" "+contextValue(merged))
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		"v1.ConfigMap.test.web a b",
+		"v1.ConfigMap.test.db a -",
+		"v1.ConfigMap.test.cache - b",
+	}
+	if diff := cmp.Diff(expected, found); diff != "" {
+		t.Errorf("unexpected objects (-want +got):\n%s", diff)
+	}
+}
+
+func contextValue(obj runtime.Object) string {
+	if obj == nil {
+		return "-"
+	}
+	value, _, _ := unstructured.NestedString(obj.(*unstructured.Unstructured).Object, "data", "value")
+	return value
+}
+
+func TestStructuredDifferContexts(t *testing.T) {
+	differ := &StructuredDiffer{Contexts: []string{"staging", "prod"}}
+	objects := []*ContextObject{
+		{name: "db", a: newContextConfigMap("db", "a")},
+		{name: "cache", b: newContextConfigMap("cache", "b")},
+	}
+	for _, obj := range objects {
+		if err := differ.Diff(obj, false, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := &bytes.Buffer{}
+	if err := differ.Run(outputText, out); diffError(err) == nil {
+		t.Fatalf("expected exit status 1, got %v", err)
+	}
+	expected := `ConfigMap test/cache (only in prod)
+ConfigMap test/db (only in staging)
+`
+	if diff := cmp.Diff(expected, out.String()); diff != "" {
+		t.Errorf("unexpected output (-want +got):\n%s", diff)
+	}
+
+	for _, obj := range differ.Report().Objects {
+		if obj.Action != ActionOnlyIn {
+			t.Errorf("expected %s to be reported as %q, got %q", obj.Name, ActionOnlyIn, obj.Action)
+		}
+	}
+}
+
+func TestContextDiffer(t *testing.T) {
+	diff, err := newContextDiffer("arn:aws:eks:us-east-1:123:cluster/staging", "prod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer diff.TearDown()
+
+	if base := filepath.Base(diff.From.Dir.Name); !strings.HasPrefix(base, "arn_aws_eks_us-east-1_123_cluster_staging-") {
+		t.Errorf("unexpected directory name %q", base)
+	}
+	if base := filepath.Base(diff.To.Dir.Name); !strings.HasPrefix(base, "prod-") {
+		t.Errorf("unexpected directory name %q", base)
+	}
+
+	secret := &ContextObject{
+		name: "v1.Secret.test.db",
+		a: &unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": "db", "namespace": "test"},
+			"data":       map[string]interface{}{"password": "c2VjcmV0"},
+		}},
+	}
+	if err := diff.Diff(secret, Printer{}, false, false); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(filepath.Join(diff.From.Dir.Name, secret.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), "password: '***'") {
+		t.Errorf("expected the secret value to be masked, got:\n%s", content)
+	}
+	content, err = os.ReadFile(filepath.Join(diff.To.Dir.Name, secret.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(content) != 0 {
+		t.Errorf("expected no secret in the second context, got:\n%s", content)
+	}
+}
+
+func TestValidateContexts(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   DiffOptions
+		expectErr string
+	}{
+		{
+			name:    "both contexts",
+			options: DiffOptions{ContextA: "staging", ContextB: "prod"},
+		},
+		{
+			name:      "single context",
+			options:   DiffOptions{ContextA: "staging"},
+			expectErr: "--context-a and --context-b must be given together",
+		},
+		{
+			name:      "server-side",
+			options:   DiffOptions{ContextA: "staging", ContextB: "prod", ServerSideApply: true},
+			expectErr: "--server-side cannot be used with --context-a and --context-b",
+		},
+		{
+			name:      "prune",
+			options:   DiffOptions{ContextA: "staging", ContextB: "prod", pruner: &pruner{}},
+			expectErr: "--prune cannot be used with --context-a and --context-b",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.options.Validate()
+			switch {
+			case len(tc.expectErr) == 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case len(tc.expectErr) > 0 && (err == nil || err.Error() != tc.expectErr):
+				t.Errorf("expected error %q, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -54,7 +54,7 @@ var (
 		Diff configurations specified by file name or stdin between the current online
 		configuration, and the configuration as it would be if applied.
 
-		The output is always YAML.
+		The output is always YAML, unless --output is given.
 
 		KUBECTL_EXTERNAL_DIFF environment variable can be used to select your own
 		diff command. Users can use external commands with params too, example:
@@ -71,14 +71,49 @@ var (
 		 >1
 		Kubectl or diff failed with an error.
 
-		Note: KUBECTL_EXTERNAL_DIFF, if used, is expected to follow that convention.`))
+		Note: KUBECTL_EXTERNAL_DIFF, if used, is expected to follow that convention.
+
+		With --output, no diff program is run: the objects are compared field by field,
+		matching the items of lists by their merge keys, and the changed fields are
+		reported by their path as text or JSON, with the same exit status. The JSON output
+		lists every object with the action applying it would take (create, update, delete,
+		prune or no-op), its changed fields, whether the changes are immaterial, as for
+		the managed fields or the generation, and whether its secret values were masked.
+
+		Fields which are expected to drift from the configuration, such as fields set
+		by controllers or mutating admission webhooks, can be left out of the diff with
+		--ignore-field and --ignore-field-manager, on the command line or as defaults
+		for the diff command in kuberc.
+
+		With --context-a and --context-b, the live objects of two clusters are diffed
+		instead, for example to spot drift between environments. The objects are those
+		of the files, or the objects of the given types selected with --selector. The
+		objects of the first context are shown as the old version and those of the
+		second context as the new one, with the same filtering of the managed fields
+		and masking of secret values. With --output, objects which exist in only one
+		of the clusters are reported with the only-in action and the name of its context.`))
 
 	diffExample = templates.Examples(i18n.T(`
 		# Diff resources included in pod.json
 		kubectl diff -f pod.json
 
 		# Diff file read from stdin
-		cat service.yaml | kubectl diff -f -`))
+		cat service.yaml | kubectl diff -f -
+
+		# Report the changed fields of the resources in a directory without an external diff program
+		kubectl diff -f dir/ -o text
+
+		# Summarize the changes as JSON, for example for policy checks
+		kubectl diff -f dir/ --prune -l app=web -o json
+
+		# Ignore the replicas managed by autoscalers and the injected sidecar containers
+		kubectl diff -f dir/ --ignore-field=Deployment.apps:.spec.replicas --ignore-field=.spec.template.spec.containers[name=istio-proxy]
+
+		# Diff the objects of the manifests in a directory between the staging and prod contexts
+		kubectl diff --context-a=staging --context-b=prod -f dir/
+
+		# Diff the deployments labeled app=web between the staging and prod contexts
+		kubectl diff --context-a=staging --context-b=prod deployments -l app=web`))
 )
 
 // Number of times we try to diff before giving-up
@@ -108,6 +143,16 @@ type DiffOptions struct {
 	ForceConflicts    bool
 	ShowManagedFields bool
 	ShowSecrets       bool
+	Output            string
+
+	IgnoreFields        []string
+	IgnoreFieldManagers []string
+	ignoreRules         IgnoreRules
+
+	ContextA         string
+	ContextB         string
+	contextFactories []cmdutil.Factory
+	args             []string
 
 	Concurrency      int
 	Selector         string
@@ -135,7 +180,7 @@ func NewDiffOptions(ioStreams genericiooptions.IOStreams) *DiffOptions {
 func NewCmdDiff(f cmdutil.Factory, streams genericiooptions.IOStreams) *cobra.Command {
 	options := NewDiffOptions(streams)
 	cmd := &cobra.Command{
-		Use:                   "diff -f FILENAME",
+		Use:                   "diff -f FILENAME | --context-a=CONTEXT --context-b=CONTEXT (-f FILENAME | TYPE [-l label])",
 		DisableFlagsInUseLine: true,
 		Short:                 i18n.T("Diff the live version against a would-be applied version"),
 		Long:                  diffLong,
@@ -171,6 +216,11 @@ func NewCmdDiff(f cmdutil.Factory, streams genericiooptions.IOStreams) *cobra.Co
 	cmd.Flags().Bool("prune", false, "Include resources that would be deleted by pruning. Can be used with -l and default shows all resources would be pruned")
 	cmd.Flags().BoolVar(&options.ShowManagedFields, "show-managed-fields", options.ShowManagedFields, "If true, include managed fields in the diff.")
 	cmd.Flags().BoolVar(&options.ShowSecrets, "show-secrets", false, "If true, do not mask secret values in the diff.")
+	cmd.Flags().StringVarP(&options.Output, "output", "o", options.Output, "Compare the objects field by field instead of running a diff program, and print the changed fields in the given format. One of: (text, json).")
+	cmd.Flags().StringArrayVar(&options.IgnoreFields, "ignore-field", options.IgnoreFields, "Leave the field out of the diff, as [KIND[.GROUP]:]PATH, for example Deployment.apps:.spec.replicas or .spec.template.spec.containers[name=istio-proxy]. Can be repeated.")
+	cmd.Flags().StringArrayVar(&options.IgnoreFieldManagers, "ignore-field-manager", options.IgnoreFieldManagers, "Leave the fields owned by the field manager out of the diff, as [KIND[.GROUP]:]NAME. Can be repeated.")
+	cmd.Flags().StringVar(&options.ContextA, "context-a", options.ContextA, "The kubeconfig context to diff the live objects of against --context-b, instead of diffing the configuration against the current context.")
+	cmd.Flags().StringVar(&options.ContextB, "context-b", options.ContextB, "The kubeconfig context to diff the live objects of against --context-a.")
 	cmd.Flags().IntVar(&options.Concurrency, "concurrency", 1, "Number of objects to process in parallel when diffing against the live version. Larger number = faster, but more memory, I/O and CPU over that shorter period of time.")
 	cmdutil.AddFilenameOptionFlags(cmd, &options.FilenameOptions, usage)
 	cmdutil.AddServerSideApplyFlags(cmd)
@@ -546,6 +596,9 @@ func (m *Masker) To() runtime.Object {
 type Differ struct {
 	From *DiffVersion
 	To   *DiffVersion
+
+	// Ignore are the fields left out of both versions.
+	Ignore IgnoreRules
 }
 
 func NewDiffer(from, to string) (*Differ, error) {
@@ -575,27 +628,64 @@ func (d *Differ) Diff(obj Object, printer Printer, showManagedFields, showSecret
 		return err
 	}
 
+	from, to, _, err = prepareVersions(from, to, d.Ignore, showManagedFields, showSecrets)
+	if err != nil {
+		return err
+	}
+
+	if err := d.From.Print(obj.Name(), from, printer); err != nil {
+		return err
+	}
+	if err := d.To.Print(obj.Name(), to, printer); err != nil {
+		return err
+	}
+	return nil
+}
+
+// prepareVersions removes the ignored fields from both versions of an object,
+// and omits their managed fields and masks their secret values, unless asked
+// to show them. It returns whether the values were masked.
+func prepareVersions(from, to runtime.Object, ignore IgnoreRules, showManagedFields, showSecrets bool) (runtime.Object, runtime.Object, bool, error) {
+	if len(ignore) > 0 {
+		// The live object tells which fields the ignored managers own,
+		// unless it does not exist yet. A copy is kept, as ignoring
+		// fields of the live object may remove its managed fields.
+		owner := from
+		if owner == nil {
+			owner = to
+		}
+		if owner != nil {
+			owner = owner.DeepCopyObject()
+		}
+		var err error
+		if from, err = ignore.apply(from, owner); err != nil {
+			return nil, nil, false, err
+		}
+		if to, err = ignore.apply(to, owner); err != nil {
+			return nil, nil, false, err
+		}
+	}
+
 	if !showManagedFields {
 		from = omitManagedFields(from)
 		to = omitManagedFields(to)
 	}
 
+	// Either version is missing when diffing an object which only exists in
+	// one of two contexts.
+	obj := to
+	if obj == nil {
+		obj = from
+	}
 	// Mask secret values if object is V1Secret
-	if gvk := to.GetObjectKind().GroupVersionKind(); !showSecrets && gvk.Version == "v1" && gvk.Kind == "Secret" {
+	if gvk := obj.GetObjectKind().GroupVersionKind(); !showSecrets && gvk.Version == "v1" && gvk.Kind == "Secret" {
 		m, err := NewMasker(from, to)
 		if err != nil {
-			return err
+			return nil, nil, false, err
 		}
-		from, to = m.From(), m.To()
-	}
-
-	if err := d.From.Print(obj.Name(), from, printer); err != nil {
-		return err
-	}
-	if err := d.To.Print(obj.Name(), to, printer); err != nil {
-		return err
+		return m.From(), m.To(), true, nil
 	}
-	return nil
+	return from, to, false, nil
 }
 
 func omitManagedFields(o runtime.Object) runtime.Object {
@@ -624,15 +714,19 @@ func isConflict(err error) bool {
 }
 
 func (o *DiffOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []string) error {
-	if len(args) != 0 {
+	crossContext := len(o.ContextA) > 0 && len(o.ContextB) > 0
+	if len(args) != 0 && !crossContext {
 		return cmdutil.UsageErrorf(cmd, "Unexpected args: %v", args)
 	}
+	o.args = args
 
 	var err error
 
-	err = o.FilenameOptions.RequireFilenameOrKustomize()
-	if err != nil {
-		return err
+	if len(args) == 0 {
+		err = o.FilenameOptions.RequireFilenameOrKustomize()
+		if err != nil {
+			return err
+		}
 	}
 
 	o.ServerSideApply = cmdutil.GetServerSideApplyFlag(cmd)
@@ -642,8 +736,11 @@ func (o *DiffOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 		return fmt.Errorf("--force-conflicts only works with --server-side")
 	}
 
-	if !o.ServerSideApply {
+	if !o.ServerSideApply || len(o.Output) > 0 {
+		// The schema is also used to match list items in the structured diff.
 		o.OpenAPIGetter = f
+	}
+	if !o.ServerSideApply {
 		openAPIV3Client, err := f.OpenAPIV3Client()
 		if err == nil {
 			o.OpenAPIV3Root = openapi3.NewRoot(openAPIV3Client)
@@ -652,6 +749,11 @@ func (o *DiffOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 		}
 	}
 
+	o.ignoreRules, err = ParseIgnoreRules(o.IgnoreFields, o.IgnoreFieldManagers)
+	if err != nil {
+		return err
+	}
+
 	o.DynamicClient, err = f.DynamicClient()
 	if err != nil {
 		return err
@@ -676,6 +778,16 @@ func (o *DiffOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 		o.pruner = newPruner(o.DynamicClient, mapper, resources, o.Selector)
 	}
 
+	if crossContext {
+		for _, context := range o.contexts() {
+			contextFactory, err := cmdutil.NewFactoryForContext(f, context)
+			if err != nil {
+				return err
+			}
+			o.contextFactories = append(o.contextFactories, contextFactory)
+		}
+	}
+
 	o.Builder = f.NewBuilder()
 	return nil
 }
@@ -684,14 +796,80 @@ func (o *DiffOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 // diff, and find each Info object for each files, and runs against the
 // differ.
 func (o *DiffOptions) Run() error {
-	differ, err := NewDiffer("LIVE", "MERGED")
+	if len(o.Output) > 0 {
+		return o.runStructured()
+	}
+
+	var differ *Differ
+	var err error
+	if len(o.contextFactories) > 0 {
+		differ, err = newContextDiffer(o.ContextA, o.ContextB)
+	} else {
+		differ, err = NewDiffer("LIVE", "MERGED")
+	}
 	if err != nil {
 		return err
 	}
 	defer differ.TearDown()
+	differ.Ignore = o.ignoreRules
 
 	printer := Printer{}
 
+	err = o.visit(func(obj Object) error {
+		return differ.Diff(obj, printer, o.ShowManagedFields, o.ShowSecrets)
+	}, func(p runtime.Object) error {
+		// Print pruned objects into old file and thus, diff
+		// command will show them as pruned.
+		name, err := getObjectName(p)
+		if err != nil {
+			klog.Warningf("pruning failed and object name could not be retrieved: %v", err)
+			return nil
+		}
+		return differ.From.Print(name, p, printer)
+	})
+	if err != nil {
+		return err
+	}
+
+	return differ.Run(o.Diff)
+}
+
+// runStructured compares the objects in process and prints the changed
+// fields in the requested output format.
+func (o *DiffOptions) runStructured() error {
+	var resources openapi.Resources
+	if o.OpenAPIGetter != nil {
+		var err error
+		resources, err = o.OpenAPIGetter.OpenAPISchema()
+		if err != nil {
+			klog.V(4).Infof("warning: unable to load the OpenAPI schema, list items will be matched by their index: %v", err)
+			resources = nil
+		}
+	}
+	differ := NewStructuredDiffer(resources)
+	differ.Ignore = o.ignoreRules
+	if len(o.contextFactories) > 0 {
+		differ.Contexts = o.contexts()
+	}
+
+	err := o.visit(func(obj Object) error {
+		return differ.Diff(obj, o.ShowManagedFields, o.ShowSecrets)
+	}, differ.AddPruned)
+	if err != nil {
+		return err
+	}
+
+	return differ.Run(o.Output, o.Diff.Out)
+}
+
+// visit finds the live and merged versions of each object and passes them
+// to diff, then passes the objects which would be deleted by pruning to pruned.
+// When diffing two contexts, the objects of both contexts are passed instead.
+func (o *DiffOptions) visit(diff func(obj Object) error, pruned func(obj runtime.Object) error) error {
+	if len(o.contextFactories) > 0 {
+		return o.visitContexts(diff)
+	}
+
 	r := o.Builder.
 		Unstructured().
 		VisitorConcurrency(o.Concurrency).
@@ -704,7 +882,7 @@ func (o *DiffOptions) Run() error {
 		return err
 	}
 
-	err = r.Visit(func(info *resource.Info, err error) error {
+	err := r.Visit(func(info *resource.Info, err error) error {
 		if err != nil {
 			return err
 		}
@@ -743,7 +921,7 @@ func (o *DiffOptions) Run() error {
 				o.tracker.MarkVisited(info)
 			}
 
-			err = differ.Diff(obj, printer, o.ShowManagedFields, o.ShowSecrets)
+			err = diff(obj)
 			if !isConflict(err) {
 				break
 			}
@@ -760,29 +938,34 @@ func (o *DiffOptions) Run() error {
 			klog.Warningf("pruning failed and could not be evaluated err: %v", err)
 		}
 
-		// Print pruned objects into old file and thus, diff
-		// command will show them as pruned.
 		for _, p := range prunedObjs {
-			name, err := getObjectName(p)
-			if err != nil {
-				klog.Warningf("pruning failed and object name could not be retrieved: %v", err)
-				continue
-			}
-			if err := differ.From.Print(name, p, printer); err != nil {
+			if err := pruned(p); err != nil {
 				return err
 			}
 		}
 	}
 
-	if err != nil {
-		return err
-	}
-
-	return differ.Run(o.Diff)
+	return err
 }
 
 // Validate makes sure provided values for DiffOptions are valid
 func (o *DiffOptions) Validate() error {
+	switch o.Output {
+	case "", outputText, outputJSON:
+	default:
+		return fmt.Errorf("invalid output format %q, must be one of (%s, %s)", o.Output, outputText, outputJSON)
+	}
+	if (len(o.ContextA) > 0) != (len(o.ContextB) > 0) {
+		return fmt.Errorf("--context-a and --context-b must be given together")
+	}
+	if len(o.ContextA) > 0 {
+		if o.ServerSideApply {
+			return fmt.Errorf("--server-side cannot be used with --context-a and --context-b")
+		}
+		if o.pruner != nil {
+			return fmt.Errorf("--prune cannot be used with --context-a and --context-b")
+		}
+	}
 	return nil
 }
 
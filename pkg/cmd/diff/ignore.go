@@ -0,0 +1,332 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/structured-merge-diff/v6/fieldpath"
+)
+
+// ignoreKindPrefix matches the KIND[.GROUP] prefix of an ignore rule.
+var ignoreKindPrefix = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9]*(\.[a-z0-9.-]+)?$`)
+
+// IgnoreRule leaves fields out of the diff, either by their path or by the
+// field manager owning them, for all kinds or for a single kind.
+type IgnoreRule struct {
+	groupKind *schema.GroupKind
+	path      []pathSegment
+	manager   string
+}
+
+// IgnoreRules are the fields left out of the diff because they are expected
+// to drift from the configuration, such as fields set by controllers or by
+// mutating admission webhooks.
+type IgnoreRules []IgnoreRule
+
+// ParseIgnoreRules parses the --ignore-field and --ignore-field-manager rules.
+// Fields are given as [KIND[.GROUP]:]PATH and managers as [KIND[.GROUP]:]NAME.
+func ParseIgnoreRules(fields, managers []string) (IgnoreRules, error) {
+	var rules IgnoreRules
+	for _, f := range fields {
+		gk, rest := splitIgnoreKind(f)
+		path, err := parseFieldPath(rest)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --ignore-field %q: %w", f, err)
+		}
+		rules = append(rules, IgnoreRule{groupKind: gk, path: path})
+	}
+	for _, m := range managers {
+		gk, rest := splitIgnoreKind(m)
+		if len(rest) == 0 {
+			return nil, fmt.Errorf("invalid --ignore-field-manager %q: empty field manager name", m)
+		}
+		rules = append(rules, IgnoreRule{groupKind: gk, manager: rest})
+	}
+	return rules, nil
+}
+
+func splitIgnoreKind(rule string) (*schema.GroupKind, string) {
+	i := strings.Index(rule, ":")
+	if i <= 0 || !ignoreKindPrefix.MatchString(rule[:i]) {
+		return nil, rule
+	}
+	gk := schema.ParseGroupKind(rule[:i])
+	return &gk, rule[i+1:]
+}
+
+func (r IgnoreRule) matches(gvk schema.GroupVersionKind) bool {
+	return r.groupKind == nil || (strings.EqualFold(r.groupKind.Kind, gvk.Kind) && r.groupKind.Group == gvk.Group)
+}
+
+// apply returns a copy of obj without the ignored fields. The managed
+// fields of owner tell which fields are owned by the ignored field managers.
+func (rules IgnoreRules) apply(obj, owner runtime.Object) (runtime.Object, error) {
+	if obj == nil || len(rules) == 0 {
+		return obj, nil
+	}
+	u, ok := obj.(*unstructured.Unstructured)
+	if ok {
+		u = u.DeepCopy()
+	} else {
+		var err error
+		if u, err = toUnstructured(obj); err != nil {
+			return nil, err
+		}
+	}
+
+	gvk := u.GroupVersionKind()
+	for _, r := range rules {
+		if !r.matches(gvk) {
+			continue
+		}
+		if len(r.manager) == 0 {
+			removePath(u.Object, r.path)
+			continue
+		}
+		for _, path := range managerPaths(owner, r.manager) {
+			removePath(u.Object, path)
+		}
+	}
+	return u, nil
+}
+
+// managerPaths returns the paths of the fields of obj owned by manager. The
+// items of lists owned by manager are removed as a whole, while only the
+// owned fields of maps are.
+func managerPaths(obj runtime.Object, manager string) [][]pathSegment {
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return nil
+	}
+	var paths [][]pathSegment
+	for _, entry := range accessor.GetManagedFields() {
+		if entry.Manager != manager || entry.FieldsV1 == nil {
+			continue
+		}
+		set := &fieldpath.Set{}
+		if err := set.FromJSON(bytes.NewReader(entry.FieldsV1.Raw)); err != nil {
+			klog.V(4).Infof("ignoring the managed fields of %s: %v", manager, err)
+			continue
+		}
+		set.Iterate(func(p fieldpath.Path) {
+			if len(p) == 0 {
+				return
+			}
+			if last := p[len(p)-1]; last.FieldName != nil && !descend(set, p).Empty() {
+				// The manager created the map, which may hold fields of others.
+				return
+			}
+			path := make([]pathSegment, 0, len(p))
+			for _, pe := range p {
+				path = append(path, segmentFor(pe))
+			}
+			paths = append(paths, path)
+		})
+	}
+	return paths
+}
+
+func descend(set *fieldpath.Set, p fieldpath.Path) *fieldpath.Set {
+	for _, pe := range p {
+		set = set.WithPrefix(pe)
+	}
+	return set
+}
+
+func segmentFor(pe fieldpath.PathElement) pathSegment {
+	switch {
+	case pe.FieldName != nil:
+		return pathSegment{field: pe.FieldName}
+	case pe.Index != nil:
+		return pathSegment{index: pe.Index}
+	case pe.Value != nil:
+		v := fmt.Sprint((*pe.Value).Unstructured())
+		return pathSegment{value: &v}
+	default:
+		keys := map[string]string{}
+		for _, f := range *pe.Key {
+			keys[f.Name] = fmt.Sprint(f.Value.Unstructured())
+		}
+		return pathSegment{keys: keys}
+	}
+}
+
+// pathSegment is an element of the path of an ignored field: a field name,
+// a list index, a list item matched by its keys or value, or a wildcard.
+type pathSegment struct {
+	field    *string
+	index    *int
+	keys     map[string]string
+	value    *string
+	wildcard bool
+}
+
+func (s pathSegment) matchesField(name string) bool {
+	return s.wildcard || (s.field != nil && *s.field == name)
+}
+
+func (s pathSegment) matchesItem(i int, item interface{}) bool {
+	switch {
+	case s.wildcard:
+		return true
+	case s.index != nil:
+		return *s.index == i
+	case s.value != nil:
+		return fmt.Sprint(item) == *s.value
+	case s.keys != nil:
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for k, v := range s.keys {
+			if fieldValue, ok := m[k]; !ok || fmt.Sprint(fieldValue) != v {
+				return false
+			}
+		}
+		return true
+	}
+	return false
+}
+
+// removePath removes the fields at path from v, and returns v.
+func removePath(v interface{}, path []pathSegment) interface{} {
+	if len(path) == 0 {
+		return v
+	}
+	seg, rest := path[0], path[1:]
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, child := range t {
+			if !seg.matchesField(k) {
+				continue
+			}
+			if len(rest) == 0 {
+				delete(t, k)
+			} else {
+				t[k] = removePath(child, rest)
+			}
+		}
+		return t
+	case []interface{}:
+		out := make([]interface{}, 0, len(t))
+		for i, item := range t {
+			if seg.matchesItem(i, item) {
+				if len(rest) == 0 {
+					continue
+				}
+				item = removePath(item, rest)
+			}
+			out = append(out, item)
+		}
+		return out
+	}
+	return v
+}
+
+// parseFieldPath parses a field path such as .spec.replicas,
+// .metadata.annotations[sidecar.istio.io/status] or
+// .spec.template.spec.containers[name=istio-proxy]. The path may also be
+// given as a JSONPath such as {.spec.containers[*].resources}.
+func parseFieldPath(path string) ([]pathSegment, error) {
+	s := strings.TrimSpace(path)
+	if strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}") {
+		s = s[1 : len(s)-1]
+	}
+	s = strings.TrimPrefix(s, "$")
+
+	var segments []pathSegment
+	for len(s) > 0 {
+		if s[0] == '[' {
+			end := strings.IndexByte(s, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("missing ]")
+			}
+			seg, err := parseBracket(s[1:end])
+			if err != nil {
+				return nil, err
+			}
+			segments = append(segments, seg)
+			s = s[end+1:]
+			continue
+		}
+		s = strings.TrimPrefix(s, ".")
+		end := strings.IndexAny(s, ".[")
+		if end < 0 {
+			end = len(s)
+		}
+		name := s[:end]
+		switch name {
+		case "":
+			return nil, fmt.Errorf("empty field name")
+		case "*":
+			segments = append(segments, pathSegment{wildcard: true})
+		default:
+			segments = append(segments, pathSegment{field: &name})
+		}
+		s = s[end:]
+	}
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("empty path")
+	}
+	return segments, nil
+}
+
+func parseBracket(b string) (pathSegment, error) {
+	switch {
+	case b == "*":
+		return pathSegment{wildcard: true}, nil
+	case strings.HasPrefix(b, "?"):
+		return pathSegment{}, fmt.Errorf("filter expressions are not supported, use [KEY=VALUE]")
+	case strings.HasPrefix(b, "="):
+		v := unquote(b[1:])
+		return pathSegment{value: &v}, nil
+	}
+	if i, err := strconv.Atoi(b); err == nil {
+		return pathSegment{index: &i}, nil
+	}
+	if !strings.Contains(b, "=") {
+		// a map key which cannot be written as a field name, such as an annotation
+		name := unquote(b)
+		return pathSegment{field: &name}, nil
+	}
+	keys := map[string]string{}
+	for _, kv := range strings.Split(b, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		if !ok || len(k) == 0 {
+			return pathSegment{}, fmt.Errorf("invalid list item selector %q, must be KEY=VALUE", kv)
+		}
+		keys[strings.TrimSpace(k)] = unquote(strings.TrimSpace(v))
+	}
+	return pathSegment{keys: keys}, nil
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+func newDeployment(replicas int64, containers ...interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":        "web",
+			"namespace":   "test",
+			"annotations": map[string]interface{}{"sidecar.istio.io/status": "injected", "owner": "team-a"},
+			"managedFields": []interface{}{
+				map[string]interface{}{
+					"manager":     "hpa",
+					"operation":   "Update",
+					"subresource": "scale",
+					"fieldsType":  "FieldsV1",
+					"fieldsV1":    map[string]interface{}{"f:spec": map[string]interface{}{"f:replicas": map[string]interface{}{}}},
+				},
+				map[string]interface{}{
+					"manager":    "injector",
+					"operation":  "Update",
+					"fieldsType": "FieldsV1",
+					"fieldsV1": map[string]interface{}{
+						"f:spec": map[string]interface{}{
+							"f:template": map[string]interface{}{
+								"f:spec": map[string]interface{}{
+									"f:containers": map[string]interface{}{
+										`k:{"name":"istio-proxy"}`: map[string]interface{}{".": map[string]interface{}{}, "f:image": map[string]interface{}{}, "f:name": map[string]interface{}{}},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{"containers": containers},
+			},
+		},
+	}}
+}
+
+func TestIgnoreRulesApply(t *testing.T) {
+	tests := []struct {
+		name       string
+		fields     []string
+		managers   []string
+		noReplicas bool
+		expected   *unstructured.Unstructured
+	}{
+		{
+			name:       "field path",
+			fields:     []string{".spec.replicas"},
+			noReplicas: true,
+			expected:   newDeployment(3, container("web", "nginx"), container("istio-proxy", "istio")),
+		},
+		{
+			name:       "field path for kind",
+			fields:     []string{"Deployment.apps:spec.replicas"},
+			noReplicas: true,
+			expected:   newDeployment(3, container("web", "nginx"), container("istio-proxy", "istio")),
+		},
+		{
+			name:     "field path for other kind",
+			fields:   []string{"StatefulSet.apps:.spec.replicas"},
+			expected: newDeployment(3, container("web", "nginx"), container("istio-proxy", "istio")),
+		},
+		{
+			name:     "list item",
+			fields:   []string{".spec.template.spec.containers[name=istio-proxy]"},
+			expected: newDeployment(3, container("web", "nginx")),
+		},
+		{
+			name:     "jsonpath wildcard",
+			fields:   []string{"{.spec.template.spec.containers[*].image}"},
+			expected: newDeployment(3, map[string]interface{}{"name": "web"}, map[string]interface{}{"name": "istio-proxy"}),
+		},
+		{
+			name:       "field manager",
+			managers:   []string{"hpa", "Deployment.apps:injector"},
+			noReplicas: true,
+			expected:   newDeployment(3, container("web", "nginx")),
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			rules, err := ParseIgnoreRules(tc.fields, tc.managers)
+			if err != nil {
+				t.Fatal(err)
+			}
+			live := newDeployment(3, container("web", "nginx"), container("istio-proxy", "istio"))
+			obj, err := rules.apply(live, live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.noReplicas {
+				unstructured.RemoveNestedField(tc.expected.Object, "spec", "replicas")
+			}
+			if diff := cmp.Diff(tc.expected.Object["spec"], obj.(*unstructured.Unstructured).Object["spec"]); diff != "" {
+				t.Errorf("unexpected spec (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(newDeployment(3, container("web", "nginx"), container("istio-proxy", "istio")).Object, live.Object); diff != "" {
+				t.Errorf("expected the live object to be left unchanged (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrepareVersionsIgnoresManagersAndManagedFields(t *testing.T) {
+	rules, err := ParseIgnoreRules([]string{".metadata.managedFields"}, []string{"hpa"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	live := newDeployment(3, container("web", "nginx"))
+	merged := newDeployment(3, container("web", "nginx"))
+	from, to, _, err := prepareVersions(live, merged, rules, true, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, obj := range []runtime.Object{from, to} {
+		u := obj.(*unstructured.Unstructured)
+		if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "spec", "replicas"); found {
+			t.Errorf("expected the replicas owned by hpa to be ignored, got %v", u.Object["spec"])
+		}
+		if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "metadata", "managedFields"); found {
+			t.Errorf("expected the managed fields to be ignored, got %v", u.Object["metadata"])
+		}
+	}
+	if len(live.GetManagedFields()) == 0 {
+		t.Errorf("expected the live object to keep its managed fields")
+	}
+}
+
+func TestIgnoreRulesAnnotation(t *testing.T) {
+	rules, err := ParseIgnoreRules([]string{".metadata.annotations[sidecar.istio.io/status]"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	obj := newDeployment(1)
+	if _, err := rules.apply(obj, obj); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"owner": "team-a"}, obj.GetAnnotations()); diff != "" {
+		t.Errorf("unexpected annotations (-want +got):\n%s", diff)
+	}
+}
+
+func TestParseIgnoreRulesErrors(t *testing.T) {
+	tests := []struct {
+		fields    []string
+		managers  []string
+		expectErr string
+	}{
+		{fields: []string{""}, expectErr: `invalid --ignore-field "": empty path`},
+		{fields: []string{".spec.containers[name=web"}, expectErr: `invalid --ignore-field ".spec.containers[name=web": missing ]`},
+		{fields: []string{`.spec.containers[?(@.name=="web")]`}, expectErr: `invalid --ignore-field ".spec.containers[?(@.name==\"web\")]": filter expressions are not supported, use [KEY=VALUE]`},
+		{fields: []string{".spec..replicas"}, expectErr: `invalid --ignore-field ".spec..replicas": empty field name`},
+		{managers: []string{"Deployment.apps:"}, expectErr: `invalid --ignore-field-manager "Deployment.apps:": empty field manager name`},
+	}
+	for _, tc := range tests {
+		t.Run(tc.expectErr, func(t *testing.T) {
+			_, err := ParseIgnoreRules(tc.fields, tc.managers)
+			if err == nil || err.Error() != tc.expectErr {
+				t.Errorf("expected error %q, got %v", tc.expectErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,505 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+	"k8s.io/kubectl/pkg/util/openapi"
+	"k8s.io/utils/exec"
+)
+
+// Output formats of the structured diff.
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// Action is what applying the configuration does to an object.
+type Action string
+
+const (
+	// ActionCreate is an object which does not exist yet.
+	ActionCreate Action = "create"
+	// ActionUpdate is an existing object which changes.
+	ActionUpdate Action = "update"
+	// ActionDelete is an existing object which is being deleted, and
+	// which the configuration would be applied to until it is gone.
+	ActionDelete Action = "delete"
+	// ActionPrune is an object which would be deleted by pruning.
+	ActionPrune Action = "prune"
+	// ActionNoOp is an existing object which does not change.
+	ActionNoOp Action = "no-op"
+	// ActionOnlyIn is an object which exists in only one of the contexts
+	// being compared, named by the Context of the ObjectDiff.
+	ActionOnlyIn Action = "only-in"
+)
+
+// ChangeType describes how a field differs between the live and merged objects.
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// FieldChange is a field which differs between the live and merged objects.
+type FieldChange struct {
+	Path   string      `json:"path"`
+	Type   ChangeType  `json:"type"`
+	Live   interface{} `json:"live"`
+	Merged interface{} `json:"merged"`
+	// Immaterial is set for fields maintained by the server, such as the
+	// managed fields or the generation, which change with any update.
+	Immaterial bool `json:"immaterial,omitempty"`
+}
+
+// ObjectDiff holds the differences between the live and merged versions of an object.
+type ObjectDiff struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+	Action     Action `json:"action"`
+	// Context is the context the object exists in, for ActionOnlyIn.
+	Context string `json:"context,omitempty"`
+	// Immaterial is set when all the changes of an updated object are immaterial.
+	Immaterial bool `json:"immaterial,omitempty"`
+	// Masked is set when the secret values of the object were masked.
+	Masked  bool          `json:"masked,omitempty"`
+	Changes []FieldChange `json:"changes,omitempty"`
+}
+
+// immaterialPaths are the fields maintained by the server which change with
+// any update, or prefixes of them.
+var immaterialPaths = []string{
+	".metadata.generation",
+	".metadata.managedFields",
+	".metadata.resourceVersion",
+}
+
+func isImmaterial(path string) bool {
+	for _, p := range immaterialPaths {
+		if path == p || strings.HasPrefix(path, p+".") || strings.HasPrefix(path, p+"[") {
+			return true
+		}
+	}
+	return false
+}
+
+// DiffReport is the result of a structured diff.
+type DiffReport struct {
+	Objects []ObjectDiff `json:"objects"`
+}
+
+// StructuredDiffer compares the live and merged versions of objects field by
+// field in process, instead of running a diff program over their YAML. The
+// items of lists are matched by their merge keys when the schema of the
+// object is known, and by their index otherwise.
+type StructuredDiffer struct {
+	// Schema returns the OpenAPI schema of the given kind, or nil if unknown.
+	Schema func(gvk schema.GroupVersionKind) proto.Schema
+	// Ignore are the fields left out of the comparison.
+	Ignore IgnoreRules
+	// Contexts are the names of the two contexts being compared, if any.
+	// Objects missing from one of them are reported as ActionOnlyIn
+	// instead of being created or pruned.
+	Contexts []string
+
+	lock    sync.Mutex
+	objects []ObjectDiff
+}
+
+// NewStructuredDiffer creates a StructuredDiffer, using resources to look up
+// the schema of the objects, if not nil.
+func NewStructuredDiffer(resources openapi.Resources) *StructuredDiffer {
+	d := &StructuredDiffer{}
+	if resources != nil {
+		d.Schema = resources.LookupResource
+	}
+	return d
+}
+
+// Diff compares the live and merged versions of an object.
+func (d *StructuredDiffer) Diff(obj Object, showManagedFields, showSecrets bool) error {
+	merged, err := obj.Merged()
+	if err != nil {
+		return err
+	}
+	live, merged, masked, err := prepareVersions(obj.Live(), merged, d.Ignore, showManagedFields, showSecrets)
+	if err != nil {
+		return err
+	}
+	return d.add(live, merged, masked)
+}
+
+// AddPruned records an object which would be deleted by pruning.
+func (d *StructuredDiffer) AddPruned(obj runtime.Object) error {
+	return d.add(obj, nil, false)
+}
+
+func (d *StructuredDiffer) add(live, merged runtime.Object, masked bool) error {
+	from, err := toUnstructured(live)
+	if err != nil {
+		return err
+	}
+	to, err := toUnstructured(merged)
+	if err != nil {
+		return err
+	}
+
+	var diff ObjectDiff
+	switch {
+	case from == nil && to == nil:
+		return nil
+	case from == nil && len(d.Contexts) == 2:
+		diff = objectDiffFor(to.GroupVersionKind(), to.GetNamespace(), to.GetName(), ActionOnlyIn)
+		diff.Context = d.Contexts[1]
+	case to == nil && len(d.Contexts) == 2:
+		diff = objectDiffFor(from.GroupVersionKind(), from.GetNamespace(), from.GetName(), ActionOnlyIn)
+		diff.Context = d.Contexts[0]
+	case from == nil:
+		diff = objectDiffFor(to.GroupVersionKind(), to.GetNamespace(), to.GetName(), ActionCreate)
+	case to == nil:
+		diff = objectDiffFor(from.GroupVersionKind(), from.GetNamespace(), from.GetName(), ActionPrune)
+	default:
+		diff = objectDiffFor(to.GroupVersionKind(), to.GetNamespace(), to.GetName(), ActionUpdate)
+		var s proto.Schema
+		if d.Schema != nil {
+			s = d.Schema(to.GroupVersionKind())
+		}
+		compareValues("", s, from.Object, to.Object, &diff.Changes)
+
+		diff.Immaterial = len(diff.Changes) > 0
+		for i := range diff.Changes {
+			diff.Changes[i].Immaterial = isImmaterial(diff.Changes[i].Path)
+			diff.Immaterial = diff.Immaterial && diff.Changes[i].Immaterial
+		}
+		switch {
+		case from.GetDeletionTimestamp() != nil:
+			diff.Action = ActionDelete
+		case len(diff.Changes) == 0:
+			diff.Action = ActionNoOp
+		}
+	}
+	diff.Masked = masked
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	d.objects = append(d.objects, diff)
+	return nil
+}
+
+func objectDiffFor(gvk schema.GroupVersionKind, namespace, name string, action Action) ObjectDiff {
+	apiVersion, kind := gvk.ToAPIVersionAndKind()
+	return ObjectDiff{
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Namespace:  namespace,
+		Name:       name,
+		Action:     action,
+	}
+}
+
+// Report returns the differences found, ordered by object.
+func (d *StructuredDiffer) Report() DiffReport {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	objects := append([]ObjectDiff{}, d.objects...)
+	sort.SliceStable(objects, func(i, j int) bool {
+		a, b := objects[i], objects[j]
+		if a.APIVersion != b.APIVersion {
+			return a.APIVersion < b.APIVersion
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return DiffReport{Objects: objects}
+}
+
+// Run prints the differences found in the given output format. Objects
+// which do not change are only included in the JSON output. Like the diff
+// program, it returns an exit error with status 1 when differences were found.
+func (d *StructuredDiffer) Run(output string, w io.Writer) error {
+	report := d.Report()
+	switch output {
+	case outputJSON:
+		data, err := json.MarshalIndent(report, "", "    ")
+		if err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w, string(data)); err != nil {
+			return err
+		}
+	default:
+		if err := printTextReport(report, w); err != nil {
+			return err
+		}
+	}
+	for _, obj := range report.Objects {
+		if obj.Action != ActionNoOp {
+			return exec.CodeExitError{Err: errors.New("differences found"), Code: 1}
+		}
+	}
+	return nil
+}
+
+func printTextReport(report DiffReport, w io.Writer) error {
+	for _, obj := range report.Objects {
+		if obj.Action == ActionNoOp {
+			continue
+		}
+		name := obj.Name
+		if len(obj.Namespace) > 0 {
+			name = obj.Namespace + "/" + name
+		}
+		kind := obj.Kind
+		if gv, err := schema.ParseGroupVersion(obj.APIVersion); err == nil && len(gv.Group) > 0 {
+			kind += "." + gv.Group
+		}
+		notes := []string{string(obj.Action)}
+		if obj.Action == ActionOnlyIn {
+			notes[0] = "only in " + obj.Context
+		}
+		if obj.Immaterial {
+			notes = append(notes, "immaterial")
+		}
+		if obj.Masked {
+			notes = append(notes, "secret values masked")
+		}
+		if _, err := fmt.Fprintf(w, "%s %s (%s)\n", kind, name, strings.Join(notes, ", ")); err != nil {
+			return err
+		}
+		for _, c := range obj.Changes {
+			var line string
+			switch c.Type {
+			case ChangeAdded:
+				line = fmt.Sprintf("  + %s: %s", c.Path, formatValue(c.Merged))
+			case ChangeRemoved:
+				line = fmt.Sprintf("  - %s: %s", c.Path, formatValue(c.Live))
+			default:
+				line = fmt.Sprintf("  ~ %s: %s -> %s", c.Path, formatValue(c.Live), formatValue(c.Merged))
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func formatValue(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// compareValues appends the differences between from and to at path to changes.
+func compareValues(path string, s proto.Schema, from, to interface{}, changes *[]FieldChange) {
+	switch f := from.(type) {
+	case map[string]interface{}:
+		if t, ok := to.(map[string]interface{}); ok {
+			compareMaps(path, s, f, t, changes)
+			return
+		}
+	case []interface{}:
+		if t, ok := to.([]interface{}); ok {
+			compareLists(path, s, f, t, changes)
+			return
+		}
+	}
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, FieldChange{Path: path, Type: ChangeModified, Live: from, Merged: to})
+	}
+}
+
+func compareMaps(path string, s proto.Schema, from, to map[string]interface{}, changes *[]FieldChange) {
+	keys := make([]string, 0, len(from)+len(to))
+	for k := range from {
+		keys = append(keys, k)
+	}
+	for k := range to {
+		if _, ok := from[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		fieldPath := path + "." + k
+		f, inFrom := from[k]
+		t, inTo := to[k]
+		switch {
+		case !inFrom:
+			*changes = append(*changes, FieldChange{Path: fieldPath, Type: ChangeAdded, Merged: t})
+		case !inTo:
+			*changes = append(*changes, FieldChange{Path: fieldPath, Type: ChangeRemoved, Live: f})
+		default:
+			compareValues(fieldPath, fieldSchema(s, k), f, t, changes)
+		}
+	}
+}
+
+func compareLists(path string, s proto.Schema, from, to []interface{}, changes *[]FieldChange) {
+	items := itemSchema(s)
+	keys := listKeys(s)
+	fromKeys, fromOK := itemKeys(keys, from)
+	toKeys, toOK := itemKeys(keys, to)
+	if len(keys) == 0 || !fromOK || !toOK {
+		// Without merge keys the items are matched by their position.
+		for i := 0; i < len(from) || i < len(to); i++ {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= len(from):
+				*changes = append(*changes, FieldChange{Path: itemPath, Type: ChangeAdded, Merged: to[i]})
+			case i >= len(to):
+				*changes = append(*changes, FieldChange{Path: itemPath, Type: ChangeRemoved, Live: from[i]})
+			default:
+				compareValues(itemPath, items, from[i], to[i], changes)
+			}
+		}
+		return
+	}
+
+	toIndex := map[string]int{}
+	for i, key := range toKeys {
+		toIndex[key] = i
+	}
+	fromIndex := map[string]int{}
+	for i, key := range fromKeys {
+		fromIndex[key] = i
+		itemPath := path + "[" + key + "]"
+		if j, ok := toIndex[key]; ok {
+			compareValues(itemPath, items, from[i], to[j], changes)
+		} else {
+			*changes = append(*changes, FieldChange{Path: itemPath, Type: ChangeRemoved, Live: from[i]})
+		}
+	}
+	for j, key := range toKeys {
+		if _, ok := fromIndex[key]; !ok {
+			*changes = append(*changes, FieldChange{Path: path + "[" + key + "]", Type: ChangeAdded, Merged: to[j]})
+		}
+	}
+}
+
+// itemKeys returns the merge keys of the list items formatted as
+// "key=value", or false if an item cannot be identified by them.
+func itemKeys(keys []string, list []interface{}) ([]string, bool) {
+	if len(keys) == 0 {
+		return nil, false
+	}
+	seen := map[string]bool{}
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		parts := make([]string, 0, len(keys))
+		for _, k := range keys {
+			v, ok := m[k]
+			if !ok {
+				if len(keys) == 1 {
+					return nil, false
+				}
+				// Compound keys may leave out optional parts.
+				continue
+			}
+			parts = append(parts, fmt.Sprintf("%s=%v", k, v))
+		}
+		key := strings.Join(parts, ",")
+		if seen[key] {
+			return nil, false
+		}
+		seen[key] = true
+		result = append(result, key)
+	}
+	return result, true
+}
+
+// listKeys returns the fields identifying the items of the list described
+// by s, from its list-map keys or its strategic merge patch merge key.
+func listKeys(s proto.Schema) []string {
+	// The extensions may be set on the field referencing the list schema,
+	// or on the list schema itself.
+	for _, candidate := range []proto.Schema{s, resolveSchema(s)} {
+		if candidate == nil {
+			continue
+		}
+		extensions := candidate.GetExtensions()
+		if keys, ok := extensions["x-kubernetes-list-map-keys"].([]interface{}); ok {
+			var result []string
+			for _, k := range keys {
+				if key, ok := k.(string); ok {
+					result = append(result, key)
+				}
+			}
+			return result
+		}
+		if key, ok := extensions["x-kubernetes-patch-merge-key"].(string); ok {
+			return []string{key}
+		}
+	}
+	return nil
+}
+
+// fieldSchema returns the schema of the field name of the object described by s.
+func fieldSchema(s proto.Schema, name string) proto.Schema {
+	switch t := resolveSchema(s).(type) {
+	case *proto.Kind:
+		return t.Fields[name]
+	case *proto.Map:
+		return t.SubType
+	}
+	return nil
+}
+
+// itemSchema returns the schema of the items of the list described by s.
+func itemSchema(s proto.Schema) proto.Schema {
+	if t, ok := resolveSchema(s).(*proto.Array); ok {
+		return t.SubType
+	}
+	return nil
+}
+
+func resolveSchema(s proto.Schema) proto.Schema {
+	for {
+		ref, ok := s.(proto.Reference)
+		if !ok {
+			return s
+		}
+		s = ref.SubSchema()
+	}
+}
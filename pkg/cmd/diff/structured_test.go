@@ -0,0 +1,340 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package diff
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/kube-openapi/pkg/util/proto"
+	"k8s.io/utils/exec"
+)
+
+// podSchema describes a pod whose containers are merged by name.
+func podSchema(gvk schema.GroupVersionKind) proto.Schema {
+	if gvk.Kind != "Pod" {
+		return nil
+	}
+	container := &proto.Kind{Fields: map[string]proto.Schema{
+		"name":  &proto.Primitive{Type: "string"},
+		"image": &proto.Primitive{Type: "string"},
+	}}
+	return &proto.Kind{Fields: map[string]proto.Schema{
+		"spec": &proto.Kind{Fields: map[string]proto.Schema{
+			"containers": &proto.Array{
+				BaseSchema: proto.BaseSchema{Extensions: map[string]interface{}{"x-kubernetes-patch-merge-key": "name"}},
+				SubType:    container,
+			},
+		}},
+	}}
+}
+
+func newPod(containers ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Pod",
+		"metadata":   map[string]interface{}{"name": "web", "namespace": "test"},
+		"spec":       map[string]interface{}{"containers": containers},
+	}
+}
+
+func container(name, image string) map[string]interface{} {
+	return map[string]interface{}{"name": name, "image": image}
+}
+
+func TestCompareValues(t *testing.T) {
+	tests := []struct {
+		name     string
+		schema   func(gvk schema.GroupVersionKind) proto.Schema
+		live     map[string]interface{}
+		merged   map[string]interface{}
+		expected []FieldChange
+	}{
+		{
+			name:   "no changes",
+			schema: podSchema,
+			live:   newPod(container("web", "nginx")),
+			merged: newPod(container("web", "nginx")),
+		},
+		{
+			name:   "reordered list with merge key",
+			schema: podSchema,
+			live:   newPod(container("web", "nginx"), container("sidecar", "envoy")),
+			merged: newPod(container("sidecar", "envoy"), container("web", "nginx:1.2")),
+			expected: []FieldChange{
+				{Path: ".spec.containers[name=web].image", Type: ChangeModified, Live: "nginx", Merged: "nginx:1.2"},
+			},
+		},
+		{
+			name:   "added and removed items with merge key",
+			schema: podSchema,
+			live:   newPod(container("web", "nginx"), container("sidecar", "envoy")),
+			merged: newPod(container("web", "nginx"), container("proxy", "haproxy")),
+			expected: []FieldChange{
+				{Path: ".spec.containers[name=sidecar]", Type: ChangeRemoved, Live: container("sidecar", "envoy")},
+				{Path: ".spec.containers[name=proxy]", Type: ChangeAdded, Merged: container("proxy", "haproxy")},
+			},
+		},
+		{
+			name:   "reordered list without schema",
+			live:   newPod(container("web", "nginx"), container("sidecar", "envoy")),
+			merged: newPod(container("sidecar", "envoy"), container("web", "nginx")),
+			expected: []FieldChange{
+				{Path: ".spec.containers[0].image", Type: ChangeModified, Live: "nginx", Merged: "envoy"},
+				{Path: ".spec.containers[0].name", Type: ChangeModified, Live: "web", Merged: "sidecar"},
+				{Path: ".spec.containers[1].image", Type: ChangeModified, Live: "envoy", Merged: "nginx"},
+				{Path: ".spec.containers[1].name", Type: ChangeModified, Live: "sidecar", Merged: "web"},
+			},
+		},
+		{
+			name:   "map fields",
+			schema: podSchema,
+			live: map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web", "old": "x"}},
+			},
+			merged: map[string]interface{}{
+				"metadata": map[string]interface{}{"labels": map[string]interface{}{"app": "web", "tier": "frontend"}},
+			},
+			expected: []FieldChange{
+				{Path: ".metadata.labels.old", Type: ChangeRemoved, Live: "x"},
+				{Path: ".metadata.labels.tier", Type: ChangeAdded, Merged: "frontend"},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var s proto.Schema
+			if tc.schema != nil {
+				s = tc.schema(schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+			}
+			var changes []FieldChange
+			compareValues("", s, tc.live, tc.merged, &changes)
+			if diff := cmp.Diff(tc.expected, changes); diff != "" {
+				t.Errorf("unexpected changes (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestStructuredDiffer(t *testing.T) {
+	differ := &StructuredDiffer{Schema: podSchema}
+	objects := []*FakeObject{
+		{name: "unchanged", live: newPod(container("web", "nginx")), merged: newPod(container("web", "nginx"))},
+		{name: "updated", live: newPod(container("web", "nginx")), merged: newPod(container("web", "nginx:1.2"))},
+		{name: "created", merged: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata":   map[string]interface{}{"name": "web", "namespace": "test"},
+		}},
+	}
+	for _, obj := range objects {
+		if err := differ.Diff(obj, false, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	pruned := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]interface{}{"name": "old", "namespace": "test"},
+	}}
+	if err := differ.AddPruned(pruned); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("text", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		err := differ.Run(outputText, out)
+		if exitErr, ok := err.(exec.ExitError); !ok || exitErr.ExitStatus() != 1 {
+			t.Fatalf("expected exit status 1, got %v", err)
+		}
+		expected := `Deployment.apps test/web (create)
+ConfigMap test/old (prune)
+Pod test/web (update)
+  ~ .spec.containers[name=web].image: "nginx" -> "nginx:1.2"
+`
+		if diff := cmp.Diff(expected, out.String()); diff != "" {
+			t.Errorf("unexpected output (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out := &bytes.Buffer{}
+		if err := differ.Run(outputJSON, out); diffError(err) == nil {
+			t.Fatalf("expected exit status 1, got %v", err)
+		}
+		expected := `{
+    "objects": [
+        {
+            "apiVersion": "apps/v1",
+            "kind": "Deployment",
+            "namespace": "test",
+            "name": "web",
+            "action": "create"
+        },
+        {
+            "apiVersion": "v1",
+            "kind": "ConfigMap",
+            "namespace": "test",
+            "name": "old",
+            "action": "prune"
+        },
+        {
+            "apiVersion": "v1",
+            "kind": "Pod",
+            "namespace": "test",
+            "name": "web",
+            "action": "no-op"
+        },
+        {
+            "apiVersion": "v1",
+            "kind": "Pod",
+            "namespace": "test",
+            "name": "web",
+            "action": "update",
+            "changes": [
+                {
+                    "path": ".spec.containers[name=web].image",
+                    "type": "modified",
+                    "live": "nginx",
+                    "merged": "nginx:1.2"
+                }
+            ]
+        }
+    ]
+}
+`
+		if diff := cmp.Diff(expected, out.String()); diff != "" {
+			t.Errorf("unexpected output (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("no differences", func(t *testing.T) {
+		differ := &StructuredDiffer{}
+		if err := differ.Diff(objects[0], false, false); err != nil {
+			t.Fatal(err)
+		}
+		out := &bytes.Buffer{}
+		if err := differ.Run(outputText, out); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if out.Len() != 0 {
+			t.Errorf("unexpected output: %q", out.String())
+		}
+	})
+}
+
+func TestStructuredDifferSummary(t *testing.T) {
+	withMetadata := func(obj map[string]interface{}, fields map[string]interface{}) map[string]interface{} {
+		metadata := obj["metadata"].(map[string]interface{})
+		for k, v := range fields {
+			metadata[k] = v
+		}
+		return obj
+	}
+	secret := func(value string) map[string]interface{} {
+		return map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Secret",
+			"metadata":   map[string]interface{}{"name": "creds", "namespace": "test"},
+			"data":       map[string]interface{}{"password": value},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		obj               *FakeObject
+		showManagedFields bool
+		expected          ObjectDiff
+	}{
+		{
+			name: "immaterial",
+			obj: &FakeObject{
+				live:   withMetadata(newPod(container("web", "nginx")), map[string]interface{}{"generation": int64(1)}),
+				merged: withMetadata(newPod(container("web", "nginx")), map[string]interface{}{"generation": int64(2)}),
+			},
+			expected: ObjectDiff{
+				APIVersion: "v1", Kind: "Pod", Namespace: "test", Name: "web", Action: ActionUpdate, Immaterial: true,
+				Changes: []FieldChange{{Path: ".metadata.generation", Type: ChangeModified, Live: int64(1), Merged: int64(2), Immaterial: true}},
+			},
+		},
+		{
+			name: "material",
+			obj: &FakeObject{
+				live:   withMetadata(newPod(container("web", "nginx")), map[string]interface{}{"generation": int64(1)}),
+				merged: withMetadata(newPod(container("web", "nginx:1.2")), map[string]interface{}{"generation": int64(2)}),
+			},
+			expected: ObjectDiff{
+				APIVersion: "v1", Kind: "Pod", Namespace: "test", Name: "web", Action: ActionUpdate,
+				Changes: []FieldChange{
+					{Path: ".metadata.generation", Type: ChangeModified, Live: int64(1), Merged: int64(2), Immaterial: true},
+					{Path: ".spec.containers[name=web].image", Type: ChangeModified, Live: "nginx", Merged: "nginx:1.2"},
+				},
+			},
+		},
+		{
+			name: "managed fields",
+			obj: &FakeObject{
+				live: withMetadata(newPod(container("web", "nginx")), map[string]interface{}{
+					"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl", "operation": "Apply"}},
+				}),
+				merged: withMetadata(newPod(container("web", "nginx")), map[string]interface{}{
+					"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl", "operation": "Update"}},
+				}),
+			},
+			showManagedFields: true,
+			expected: ObjectDiff{
+				APIVersion: "v1", Kind: "Pod", Namespace: "test", Name: "web", Action: ActionUpdate, Immaterial: true,
+				Changes: []FieldChange{{Path: ".metadata.managedFields[0].operation", Type: ChangeModified, Live: "Apply", Merged: "Update", Immaterial: true}},
+			},
+		},
+		{
+			name: "deleting",
+			obj: &FakeObject{
+				live:   withMetadata(newPod(container("web", "nginx")), map[string]interface{}{"deletionTimestamp": "2026-01-01T00:00:00Z"}),
+				merged: withMetadata(newPod(container("web", "nginx")), map[string]interface{}{"deletionTimestamp": "2026-01-01T00:00:00Z"}),
+			},
+			expected: ObjectDiff{APIVersion: "v1", Kind: "Pod", Namespace: "test", Name: "web", Action: ActionDelete},
+		},
+		{
+			name: "masked secret",
+			obj:  &FakeObject{live: secret("YQ=="), merged: secret("Yg==")},
+			expected: ObjectDiff{
+				APIVersion: "v1", Kind: "Secret", Namespace: "test", Name: "creds", Action: ActionUpdate, Masked: true,
+				Changes: []FieldChange{{Path: ".data.password", Type: ChangeModified, Live: sensitiveMaskBefore, Merged: sensitiveMaskAfter}},
+			},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			differ := &StructuredDiffer{Schema: podSchema}
+			if err := differ.Diff(tc.obj, tc.showManagedFields, false); err != nil {
+				t.Fatal(err)
+			}
+			report := differ.Report()
+			if len(report.Objects) != 1 {
+				t.Fatalf("expected 1 object, got %d", len(report.Objects))
+			}
+			if diff := cmp.Diff(tc.expected, report.Objects[0]); diff != "" {
+				t.Errorf("unexpected object diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
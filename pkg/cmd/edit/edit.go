@@ -55,7 +55,31 @@ var (
 		that contains your unapplied changes. The most common error when updating a resource
 		is another editor changing the resource on the server. When this occurs, you will have
 		to apply your changes to the newer version of the resource, or update your temporary
-		saved copy to include the latest resource version.`))
+		saved copy to include the latest resource version.
+
+		When several objects are edited and some of them fail to be saved, the file is reopened
+		with the status of each object. Changing the status of an object to [skip] leaves it
+		out of the next save, and changing it to [revert] restores it as it was before editing.
+		A file saved by a failed edit can be edited again later with --resume, which saves its
+		edits again before reopening it in case of errors.
+
+		With --server-side, the edited object is submitted with server-side apply instead of
+		a patch, without the fields populated by the server. Changing fields owned by other
+		field managers is then reported as a conflict, and the editor is reopened so the
+		changes can be reverted, unless --force-conflicts is given to take their ownership.
+
+		With --minimal, the status and the metadata populated by the server, such as the
+		resource version, uid and creation timestamp, are left out of the edited objects and
+		kept unchanged, so that only the fields you changed are sent. With --strip-defaults,
+		the fields equal to the default of their schema are left out as well.
+
+		With --hook, or the KUBE_EDIT_HOOK environment variable, the objects are edited by a
+		program instead of the editor. The program receives the objects on its standard input,
+		without the comments, and writes on its standard output either the edited objects or a
+		JSON patch to apply to them. The edited objects are then validated and saved like the
+		ones edited in the editor. If they fail to be saved, the command fails instead of
+		reopening the file, except on conflicts with concurrent changes, where the program is
+		run again on the latest version of the objects.`))
 
 	editExample = templates.Examples(i18n.T(`
 		# Edit the service named 'registry'
@@ -71,14 +95,29 @@ var (
 		kubectl edit deployment/mydeployment -o yaml --save-config
 
 		# Edit the 'status' subresource for the 'mydeployment' deployment
-		kubectl edit deployment mydeployment --subresource='status'`))
+		kubectl edit deployment mydeployment --subresource='status'
+
+		# Edit the deployment 'mydeployment' with server-side apply, reporting conflicts with other field managers
+		kubectl edit deployment/mydeployment --server-side
+
+		# Edit the deployment 'mydeployment' without its status, server populated metadata and defaulted fields
+		kubectl edit deployment/mydeployment --minimal --strip-defaults
+
+		# Save the edits of a previously failed edit again
+		kubectl edit --resume=/tmp/kubectl-edit-1234.yaml
+
+		# Scale the deployment 'mydeployment' with a program instead of the editor
+		kubectl edit deployment/mydeployment --hook="yq '.spec.replicas = 3'"
+
+		# Add a label to the deployment 'mydeployment' with a JSON patch written by a program
+		KUBE_EDIT_HOOK="echo '[{\"op\": \"add\", \"path\": \"/metadata/labels/tier\", \"value\": \"web\"}]'" kubectl edit deployment/mydeployment`))
 )
 
 // NewCmdEdit creates the `edit` command
 func NewCmdEdit(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.Command {
 	o := editor.NewEditOptions(editor.NormalEditMode, ioStreams)
 	cmd := &cobra.Command{
-		Use:                   "edit (RESOURCE/NAME | -f FILENAME)",
+		Use:                   "edit (RESOURCE/NAME | -f FILENAME | --resume=FILENAME)",
 		DisableFlagsInUseLine: true,
 		Short:                 i18n.T("Edit a resource on the server"),
 		Long:                  editLong,
@@ -102,6 +141,12 @@ func NewCmdEdit(f cmdutil.Factory, ioStreams genericiooptions.IOStreams) *cobra.
 	cmd.Flags().BoolVar(&o.WindowsLineEndings, "windows-line-endings", o.WindowsLineEndings,
 		"Defaults to the line ending native to your platform.")
 	cmdutil.AddFieldManagerFlagVar(cmd, &o.FieldManager, "kubectl-edit")
+	cmd.Flags().BoolVar(&o.ServerSideApply, "server-side", o.ServerSideApply, "If true, submit the edited object with server-side apply instead of a patch, and report conflicts with other field managers.")
+	cmd.Flags().BoolVar(&o.ForceConflicts, "force-conflicts", o.ForceConflicts, "If true, server-side apply will force the changes against conflicts.")
+	cmd.Flags().StringVar(&o.Resume, "resume", o.Resume, "The file saved by a previous edit, whose edits are saved again instead of launching the editor first.")
+	cmd.Flags().StringVar(&o.EditHook, "hook", o.EditHook, "A program run instead of the editor, which receives the objects on its standard input and writes the edited objects, or a JSON patch to apply to them, on its standard output. Defaults to the KUBE_EDIT_HOOK environment variable.")
+	cmd.Flags().BoolVar(&o.Minimal, "minimal", o.Minimal, "If true, leave the status and the metadata populated by the server out of the edited objects, and keep them unchanged.")
+	cmd.Flags().BoolVar(&o.StripDefaults, "strip-defaults", o.StripDefaults, "If true, also leave the fields equal to the default of their schema out of the edited objects. Requires --minimal.")
 	cmdutil.AddApplyAnnotationVarFlags(cmd, &o.ApplyAnnotation)
 	cmdutil.AddSubresourceFlags(cmd, &o.Subresource, "If specified, edit will operate on the subresource of the requested object.")
 	return cmd
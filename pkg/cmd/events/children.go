@@ -0,0 +1,295 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"context"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+)
+
+// objectKey identifies the involved object of an event.
+type objectKey struct {
+	kind string
+	name string
+}
+
+// ownerWalker finds the objects owned, directly or indirectly, by an
+// object, following ownerReferences from Deployments to ReplicaSets,
+// from CronJobs to Jobs and from workloads to Pods. The
+// PersistentVolumeClaims used by the Pods found are included as well.
+// Lists are cached, so every kind is listed at most once.
+type ownerWalker struct {
+	client    kubernetes.Interface
+	namespace string
+
+	replicaSets []appsv1.ReplicaSet
+	jobs        []batchv1.Job
+	pods        []corev1.Pod
+	// unrelated are the objects resolve found not to be related.
+	unrelated sets.Set[objectKey]
+}
+
+// relatedObjects returns the object of the given kind and name together
+// with all objects it owns.
+func (w *ownerWalker) relatedObjects(ctx context.Context, gvk schema.GroupVersionKind, name string) ([]objectKey, error) {
+	uid, err := w.uidOf(ctx, gvk, name)
+	if err != nil {
+		return nil, err
+	}
+
+	related := []objectKey{{kind: gvk.Kind, name: name}}
+	if gvk.Kind == "Pod" {
+		pod, err := w.client.CoreV1().Pods(w.namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return nil, err
+		}
+		return append(related, claimsOf(pod)...), nil
+	}
+	if len(uid) == 0 {
+		return related, nil
+	}
+
+	type owner struct {
+		kind string
+		uid  types.UID
+	}
+	queue := []owner{{kind: gvk.Kind, uid: uid}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		switch current.kind {
+		case "Deployment":
+			if err := w.listReplicaSets(ctx); err != nil {
+				return nil, err
+			}
+			for _, rs := range w.replicaSets {
+				if ownedBy(rs.OwnerReferences, current.uid) {
+					related = append(related, objectKey{kind: "ReplicaSet", name: rs.Name})
+					queue = append(queue, owner{kind: "ReplicaSet", uid: rs.UID})
+				}
+			}
+		case "CronJob":
+			if err := w.listJobs(ctx); err != nil {
+				return nil, err
+			}
+			for _, job := range w.jobs {
+				if ownedBy(job.OwnerReferences, current.uid) {
+					related = append(related, objectKey{kind: "Job", name: job.Name})
+					queue = append(queue, owner{kind: "Job", uid: job.UID})
+				}
+			}
+		case "ReplicaSet", "StatefulSet", "DaemonSet", "Job":
+			if err := w.listPods(ctx); err != nil {
+				return nil, err
+			}
+			for i := range w.pods {
+				pod := &w.pods[i]
+				if ownedBy(pod.OwnerReferences, current.uid) {
+					related = append(related, objectKey{kind: "Pod", name: pod.Name})
+					related = append(related, claimsOf(pod)...)
+				}
+			}
+		}
+	}
+	return related, nil
+}
+
+// resolve follows the ownerReferences of an object which was not found
+// when the related objects were computed, e.g. a pod created by a
+// rollout while watching. If one of its owners is related, the object
+// and the claims of pods are added to related and resolve returns true.
+// Objects which no longer exist are not related.
+func (w *ownerWalker) resolve(ctx context.Context, key objectKey, related sets.Set[objectKey]) (bool, error) {
+	if w.unrelated.Has(key) {
+		return false, nil
+	}
+	obj, claims, err := w.lookup(ctx, key)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil || obj == nil {
+		return false, err
+	}
+
+	for _, ref := range obj.GetOwnerReferences() {
+		owner := objectKey{kind: ref.Kind, name: ref.Name}
+		if !related.Has(owner) {
+			ownerRelated, err := w.resolve(ctx, owner, related)
+			if err != nil {
+				return false, err
+			}
+			if !ownerRelated {
+				continue
+			}
+		}
+		related.Insert(key)
+		related.Insert(claims...)
+		return true, nil
+	}
+	if w.unrelated == nil {
+		w.unrelated = sets.New[objectKey]()
+	}
+	w.unrelated.Insert(key)
+	return false, nil
+}
+
+// lookup returns the object of the given key, and the claims of pods, from
+// the cached lists. Objects created since the kind was listed are read from
+// the server and added to the cached list. lookup returns nil for the kinds
+// the walker does not follow.
+func (w *ownerWalker) lookup(ctx context.Context, key objectKey) (metav1.Object, []objectKey, error) {
+	switch key.kind {
+	case "Pod":
+		if err := w.listPods(ctx); err != nil {
+			return nil, nil, err
+		}
+		for i := range w.pods {
+			if w.pods[i].Name == key.name {
+				return &w.pods[i], claimsOf(&w.pods[i]), nil
+			}
+		}
+		pod, err := w.client.CoreV1().Pods(w.namespace).Get(ctx, key.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		w.pods = append(w.pods, *pod)
+		return pod, claimsOf(pod), nil
+	case "ReplicaSet":
+		if err := w.listReplicaSets(ctx); err != nil {
+			return nil, nil, err
+		}
+		for i := range w.replicaSets {
+			if w.replicaSets[i].Name == key.name {
+				return &w.replicaSets[i], nil, nil
+			}
+		}
+		rs, err := w.client.AppsV1().ReplicaSets(w.namespace).Get(ctx, key.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		w.replicaSets = append(w.replicaSets, *rs)
+		return rs, nil, nil
+	case "Job":
+		if err := w.listJobs(ctx); err != nil {
+			return nil, nil, err
+		}
+		for i := range w.jobs {
+			if w.jobs[i].Name == key.name {
+				return &w.jobs[i], nil, nil
+			}
+		}
+		job, err := w.client.BatchV1().Jobs(w.namespace).Get(ctx, key.name, metav1.GetOptions{})
+		if err != nil {
+			return nil, nil, err
+		}
+		w.jobs = append(w.jobs, *job)
+		return job, nil, nil
+	}
+	return nil, nil, nil
+}
+
+// uidOf returns the UID of the named object, or an empty UID for kinds
+// which cannot own any of the objects the walker follows.
+func (w *ownerWalker) uidOf(ctx context.Context, gvk schema.GroupVersionKind, name string) (types.UID, error) {
+	var obj metav1.Object
+	var err error
+	switch gvk.GroupKind() {
+	case schema.GroupKind{Group: "apps", Kind: "Deployment"}:
+		obj, err = w.client.AppsV1().Deployments(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "apps", Kind: "ReplicaSet"}:
+		obj, err = w.client.AppsV1().ReplicaSets(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "apps", Kind: "StatefulSet"}:
+		obj, err = w.client.AppsV1().StatefulSets(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "apps", Kind: "DaemonSet"}:
+		obj, err = w.client.AppsV1().DaemonSets(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "batch", Kind: "Job"}:
+		obj, err = w.client.BatchV1().Jobs(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	case schema.GroupKind{Group: "batch", Kind: "CronJob"}:
+		obj, err = w.client.BatchV1().CronJobs(w.namespace).Get(ctx, name, metav1.GetOptions{})
+	default:
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return obj.GetUID(), nil
+}
+
+func (w *ownerWalker) listReplicaSets(ctx context.Context) error {
+	if w.replicaSets != nil {
+		return nil
+	}
+	list, err := w.client.AppsV1().ReplicaSets(w.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	w.replicaSets = append([]appsv1.ReplicaSet{}, list.Items...)
+	return nil
+}
+
+func (w *ownerWalker) listJobs(ctx context.Context) error {
+	if w.jobs != nil {
+		return nil
+	}
+	list, err := w.client.BatchV1().Jobs(w.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	w.jobs = append([]batchv1.Job{}, list.Items...)
+	return nil
+}
+
+func (w *ownerWalker) listPods(ctx context.Context) error {
+	if w.pods != nil {
+		return nil
+	}
+	list, err := w.client.CoreV1().Pods(w.namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+	w.pods = append([]corev1.Pod{}, list.Items...)
+	return nil
+}
+
+// claimsOf returns the PersistentVolumeClaims mounted by pod.
+func claimsOf(pod *corev1.Pod) []objectKey {
+	var claims []objectKey
+	for _, v := range pod.Spec.Volumes {
+		if v.PersistentVolumeClaim != nil {
+			claims = append(claims, objectKey{kind: "PersistentVolumeClaim", name: v.PersistentVolumeClaim.ClaimName})
+		}
+	}
+	return claims
+}
+
+func ownedBy(refs []metav1.OwnerReference, uid types.UID) bool {
+	for _, ref := range refs {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
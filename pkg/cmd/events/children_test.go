@@ -0,0 +1,226 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	fakeexternal "k8s.io/client-go/kubernetes/fake"
+)
+
+func ownedObjects() []runtime.Object {
+	owner := func(kind, name string, uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, UID: uid}}
+	}
+	return []runtime.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "foo", UID: "deploy-uid"}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "foo", UID: "rs-uid", OwnerReferences: owner("Deployment", "web", "deploy-uid")}},
+		&appsv1.ReplicaSet{ObjectMeta: metav1.ObjectMeta{Name: "other-1", Namespace: "foo", UID: "other-rs-uid"}},
+		&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "web-1-abc", Namespace: "foo", UID: "pod-uid", OwnerReferences: owner("ReplicaSet", "web-1", "rs-uid")},
+			Spec: corev1.PodSpec{
+				Volumes: []corev1.Volume{{
+					Name:         "data",
+					VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data"}},
+				}},
+			},
+		},
+		&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "other-1-abc", Namespace: "foo", OwnerReferences: owner("ReplicaSet", "other-1", "other-rs-uid")}},
+	}
+}
+
+func TestRelatedObjects(t *testing.T) {
+	walker := &ownerWalker{client: fakeexternal.NewSimpleClientset(ownedObjects()...), namespace: "foo"}
+	related, err := walker.relatedObjects(t.Context(), schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []objectKey{
+		{kind: "Deployment", name: "web"},
+		{kind: "ReplicaSet", name: "web-1"},
+		{kind: "Pod", name: "web-1-abc"},
+		{kind: "PersistentVolumeClaim", name: "web-data"},
+	}
+	if len(related) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, related)
+	}
+	for i := range expected {
+		if related[i] != expected[i] {
+			t.Errorf("expected %v, got %v", expected, related)
+		}
+	}
+}
+
+func TestResolveUsesCachedObjects(t *testing.T) {
+	client := fakeexternal.NewSimpleClientset(ownedObjects()...)
+	walker := &ownerWalker{client: client, namespace: "foo"}
+	objects, err := walker.relatedObjects(t.Context(), schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"}, "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	related := sets.New[objectKey](objects...)
+
+	// the pod and its owner were listed with the related objects
+	client.ClearActions()
+	for i := 0; i < 2; i++ {
+		if ok, err := walker.resolve(t.Context(), objectKey{kind: "Pod", name: "other-1-abc"}, related); err != nil || ok {
+			t.Errorf("expected other-1-abc not to be related, got %v, %v", ok, err)
+		}
+	}
+	if actions := client.Actions(); len(actions) != 0 {
+		t.Errorf("expected no requests for listed objects, got %v", actions)
+	}
+
+	// a pod created since then is read once and cached
+	if _, err := client.CoreV1().Pods("foo").Create(t.Context(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1-def", Namespace: "foo", OwnerReferences: []metav1.OwnerReference{{Kind: "ReplicaSet", Name: "web-1", UID: "rs-uid"}}},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	client.ClearActions()
+	if ok, err := walker.resolve(t.Context(), objectKey{kind: "Pod", name: "web-1-def"}, related); err != nil || !ok {
+		t.Errorf("expected web-1-def to be related, got %v, %v", ok, err)
+	}
+	if _, _, err := walker.lookup(t.Context(), objectKey{kind: "Pod", name: "web-1-def"}); err != nil {
+		t.Fatal(err)
+	}
+	if actions := client.Actions(); len(actions) != 1 || !actions[0].Matches("get", "pods") {
+		t.Errorf("expected a single get of the new pod, got %v", actions)
+	}
+}
+
+func TestEventsIncludeChildren(t *testing.T) {
+	event := func(name, kind, involved string) runtime.Object {
+		return &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "foo"},
+			InvolvedObject: corev1.ObjectReference{Kind: kind, Name: involved, Namespace: "foo"},
+			Type:           corev1.EventTypeNormal,
+			Reason:         "Testing",
+			Message:        "event of " + involved,
+			EventTime:      metav1.NewMicroTime(time.Now().Add(-10 * time.Minute)),
+		}
+	}
+	objects := append(ownedObjects(),
+		event("e1", "Deployment", "web"),
+		event("e2", "ReplicaSet", "web-1"),
+		event("e3", "Pod", "web-1-abc"),
+		event("e4", "PersistentVolumeClaim", "web-data"),
+		event("e5", "Pod", "other-1-abc"),
+	)
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	printer := NewEventPrinter(false, false)
+	options := &EventsOptions{
+		Namespace: "foo",
+		client:    fakeexternal.NewSimpleClientset(objects...),
+		PrintObj: func(object runtime.Object, writer io.Writer) error {
+			return printer.PrintObj(object, writer)
+		},
+		IOStreams: streams,
+
+		forGVK:          schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		forName:         "web",
+		includeChildren: true,
+	}
+
+	if err := options.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	out := buf.String()
+	for _, expected := range []string{"event of web\n", "event of web-1\n", "event of web-1-abc\n", "event of web-data\n"} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected output to contain %q, got\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "other-1-abc") {
+		t.Errorf("expected output not to contain events of unrelated objects, got\n%s", out)
+	}
+}
+
+func TestFilteredInvolvedObjectResolvesNewObjects(t *testing.T) {
+	client := fakeexternal.NewSimpleClientset(ownedObjects()...)
+	options := &EventsOptions{
+		Namespace:       "foo",
+		client:          client,
+		forGVK:          schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"},
+		forName:         "web",
+		includeChildren: true,
+	}
+	options.walker = &ownerWalker{client: client, namespace: "foo"}
+	related, err := options.walker.relatedObjects(t.Context(), options.forGVK, options.forName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	options.relatedObjects = sets.New[objectKey](related...)
+	options.unrelatedObjects = sets.New[objectKey]()
+
+	// a rollout creates a new replica set and pod after the related
+	// objects have been computed
+	owner := func(kind, name string, uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{Kind: kind, Name: name, UID: uid}}
+	}
+	if _, err := client.AppsV1().ReplicaSets("foo").Create(t.Context(), &appsv1.ReplicaSet{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-2", Namespace: "foo", UID: "rs-2-uid", OwnerReferences: owner("Deployment", "web", "deploy-uid")},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.CoreV1().Pods("foo").Create(t.Context(), &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-2-abc", Namespace: "foo", OwnerReferences: owner("ReplicaSet", "web-2", "rs-2-uid")},
+		Spec: corev1.PodSpec{
+			Volumes: []corev1.Volume{{
+				Name:         "data",
+				VolumeSource: corev1.VolumeSource{PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "web-data-2"}},
+			}},
+		},
+	}, metav1.CreateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		kind     string
+		name     string
+		expected bool
+	}{
+		{kind: "Pod", name: "web-2-abc", expected: true},
+		{kind: "ReplicaSet", name: "web-2", expected: true},
+		{kind: "PersistentVolumeClaim", name: "web-data-2", expected: true},
+		{kind: "Pod", name: "other-1-abc", expected: false},
+		{kind: "Pod", name: "missing", expected: false},
+	}
+	for _, test := range tests {
+		event := corev1.Event{InvolvedObject: corev1.ObjectReference{Kind: test.kind, Name: test.name}}
+		related, err := options.filteredInvolvedObject(t.Context(), event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if related != test.expected {
+			t.Errorf("%s/%s: expected related to be %v, got %v", test.kind, test.name, test.expected, related)
+		}
+	}
+}
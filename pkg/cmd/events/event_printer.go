@@ -34,6 +34,9 @@ import (
 type EventPrinter struct {
 	NoHeaders     bool
 	AllNamespaces bool
+	// Wide adds the action, the reporting controller and the related
+	// object of the events as columns.
+	Wide bool
 
 	headersPrinted bool
 }
@@ -63,7 +66,11 @@ func (ep *EventPrinter) printHeadings(w io.Writer) {
 	if ep.AllNamespaces {
 		fmt.Fprintf(w, "NAMESPACE\t")
 	}
-	fmt.Fprintf(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\tMESSAGE\n")
+	fmt.Fprintf(w, "LAST SEEN\tTYPE\tREASON\tOBJECT\t")
+	if ep.Wide {
+		fmt.Fprintf(w, "ACTION\tCONTROLLER\tRELATED\t")
+	}
+	fmt.Fprintf(w, "MESSAGE\n")
 }
 
 func (ep *EventPrinter) printOneEvent(w io.Writer, e corev1.Event) {
@@ -71,14 +78,45 @@ func (ep *EventPrinter) printOneEvent(w io.Writer, e corev1.Event) {
 	if ep.AllNamespaces {
 		fmt.Fprintf(w, "%v\t", e.Namespace)
 	}
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t%v\n",
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s/%s\t",
 		interval,
 		printers.EscapeTerminal(e.Type),
 		printers.EscapeTerminal(e.Reason),
 		printers.EscapeTerminal(e.InvolvedObject.Kind),
 		printers.EscapeTerminal(e.InvolvedObject.Name),
-		printers.EscapeTerminal(strings.TrimSpace(e.Message)),
 	)
+	if ep.Wide {
+		fmt.Fprintf(w, "%s\t%s\t%s\t",
+			printers.EscapeTerminal(valueOrNone(e.Action)),
+			printers.EscapeTerminal(valueOrNone(reportingController(e))),
+			printers.EscapeTerminal(relatedObject(e)),
+		)
+	}
+	fmt.Fprintf(w, "%v\n", printers.EscapeTerminal(strings.TrimSpace(e.Message)))
+}
+
+// reportingController returns the controller which emitted the event,
+// falling back to the source component of events created through the
+// core/v1 API.
+func reportingController(e corev1.Event) string {
+	if len(e.ReportingController) > 0 {
+		return e.ReportingController
+	}
+	return e.Source.Component
+}
+
+func relatedObject(e corev1.Event) string {
+	if e.Related == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%s/%s", e.Related.Kind, e.Related.Name)
+}
+
+func valueOrNone(s string) string {
+	if len(s) == 0 {
+		return "<none>"
+	}
+	return s
 }
 
 func getInterval(e corev1.Event) string {
@@ -243,6 +243,58 @@ foo	12m (x3 over 20m)	Normal	ScalingReplicaSet	Deployment/bar	Scaled up replica
 			},
 			expected: `LAST SEEN	TYPE	REASON	OBJECT	MESSAGE
 60s (x3 over 20m)	test^[	test^[	Deployment/bar^[	^[
+`,
+		},
+		{
+			printer: EventPrinter{
+				NoHeaders:     false,
+				AllNamespaces: false,
+				Wide:          true,
+			},
+			obj: &corev1.EventList{
+				Items: []corev1.Event{
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "web-000",
+							Namespace: "foo",
+						},
+						InvolvedObject: corev1.ObjectReference{
+							Kind:      "Pod",
+							Name:      "web",
+							Namespace: "foo",
+						},
+						Related: &corev1.ObjectReference{
+							Kind: "Node",
+							Name: "node-1",
+						},
+						Type:                corev1.EventTypeNormal,
+						Reason:              "Scheduled",
+						Action:              "Binding",
+						Message:             "Successfully assigned foo/web to node-1",
+						ReportingController: "default-scheduler",
+						EventTime:           metav1.NewMicroTime(time.Now().Add(-20 * time.Minute)),
+					},
+					{
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "web-001",
+							Namespace: "foo",
+						},
+						InvolvedObject: corev1.ObjectReference{
+							Kind:      "Pod",
+							Name:      "web",
+							Namespace: "foo",
+						},
+						Type:           corev1.EventTypeNormal,
+						Reason:         "Pulled",
+						Message:        "Container image already present on machine",
+						Source:         corev1.EventSource{Component: "kubelet"},
+						FirstTimestamp: metav1.NewTime(time.Now().Add(-19 * time.Minute)),
+					},
+				},
+			},
+			expected: `LAST SEEN	TYPE	REASON	OBJECT	ACTION	CONTROLLER	RELATED	MESSAGE
+20m	Normal	Scheduled	Pod/web	Binding	default-scheduler	Node/node-1	Successfully assigned foo/web to node-1
+19m	Normal	Pulled	Pod/web	<none>	kubelet	<none>	Container image already present on machine
 `,
 		},
 	}
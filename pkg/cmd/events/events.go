@@ -27,6 +27,7 @@ import (
 	"github.com/spf13/cobra"
 
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -42,6 +43,7 @@ import (
 	"k8s.io/client-go/kubernetes/scheme"
 	watchtools "k8s.io/client-go/tools/watch"
 	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"k8s.io/kubectl/pkg/util"
 	"k8s.io/kubectl/pkg/util/i18n"
 	"k8s.io/kubectl/pkg/util/interrupt"
 	"k8s.io/kubectl/pkg/util/templates"
@@ -65,11 +67,24 @@ var (
 	# List recent events for the specified pod, then wait for more events and list them as they arrive
 	kubectl events --for pod/web-pod-13je7 --watch
 
+	# List recent events for the specified deployment and the replica sets, pods
+	# and persistent volume claims it owns
+	kubectl events --for deployment/web --include-children
+
 	# List recent events in YAML format
 	kubectl events -oyaml
 
+	# List events of the last hour as events.k8s.io/v1 objects in JSON format
+	kubectl events --since=1h --output-version=events.k8s.io/v1 -ojson
+
+	# List recent events including their action, reporting controller and related object
+	kubectl events -owide
+
 	# List recent only events of type 'Warning' or 'Normal'
-	kubectl events --types=Warning,Normal`))
+	kubectl events --types=Warning,Normal
+
+	# Summarize recent events in all namespaces, grouping similar events together
+	kubectl events --all-namespaces --summary`))
 )
 
 // EventsFlags directly reflect the information that CLI is gathering via flags.  They will be converted to Options, which
@@ -82,9 +97,18 @@ type EventsFlags struct {
 	AllNamespaces bool
 	Watch         bool
 	NoHeaders     bool
+	Summary       bool
 	ForObject     string
 	FilterTypes   []string
 	ChunkSize     int64
+	// IncludeChildren extends ForObject to the objects it owns.
+	IncludeChildren bool
+	// Since and Until limit the events to those last observed within
+	// a time window. Both accept an RFC3339 timestamp or a duration
+	// relative to now.
+	Since         string
+	Until         string
+	OutputVersion string
 	genericiooptions.IOStreams
 }
 
@@ -95,6 +119,7 @@ func NewEventsFlags(restClientGetter genericclioptions.RESTClientGetter, streams
 		PrintFlags:       genericclioptions.NewPrintFlags("events").WithTypeSetter(scheme.Scheme),
 		IOStreams:        streams,
 		ChunkSize:        cmdutil.DefaultChunkSize,
+		OutputVersion:    corev1.SchemeGroupVersion.String(),
 	}
 }
 
@@ -105,11 +130,33 @@ type EventsOptions struct {
 	AllNamespaces bool
 	Watch         bool
 	FilterTypes   []string
+	NoHeaders     bool
+	// Summary groups similar events and prints one row per group
+	// instead of one row per event.
+	Summary bool
 
 	forGVK  schema.GroupVersionKind
 	forName string
-
-	client *kubernetes.Clientset
+	// includeChildren shows the events of the objects owned by the
+	// --for object too.
+	includeChildren bool
+	relatedObjects  sets.Set[objectKey]
+	// walker resolves the owners of the involved objects which are not
+	// related yet, e.g. pods created while watching, and
+	// unrelatedObjects remembers the ones it did not relate.
+	walker           *ownerWalker
+	unrelatedObjects sets.Set[objectKey]
+
+	// since and until bound the time events were last observed at,
+	// a zero time leaves the window open on that side.
+	since time.Time
+	until time.Time
+
+	client kubernetes.Interface
+	// eventsV1 lists and watches events through the events.k8s.io/v1
+	// API, which is served by all supported servers; events are read
+	// through core/v1 when it is not.
+	eventsV1 bool
 
 	PrintObj printers.ResourcePrinterFunc
 
@@ -121,7 +168,7 @@ func NewCmdEvents(restClientGetter genericclioptions.RESTClientGetter, streams g
 	flags := NewEventsFlags(restClientGetter, streams)
 
 	cmd := &cobra.Command{
-		Use:                   fmt.Sprintf("events [(-o|--output=)%s] [--for TYPE/NAME] [--watch] [--types=Normal,Warning]", strings.Join(flags.PrintFlags.AllowedFormats(), "|")),
+		Use:                   fmt.Sprintf("events [(-o|--output=)%s] [--for TYPE/NAME] [--watch] [--types=Normal,Warning]", strings.Join(append(flags.PrintFlags.AllowedFormats(), "wide"), "|")),
 		DisableFlagsInUseLine: true,
 		Short:                 i18n.T("List events"),
 		Long:                  eventsLong,
@@ -143,8 +190,13 @@ func (flags *EventsFlags) AddFlags(cmd *cobra.Command) {
 	cmd.Flags().BoolVarP(&flags.Watch, "watch", "w", flags.Watch, "After listing the requested events, watch for more events.")
 	cmd.Flags().BoolVarP(&flags.AllNamespaces, "all-namespaces", "A", flags.AllNamespaces, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
 	cmd.Flags().StringVar(&flags.ForObject, "for", flags.ForObject, "Filter events to only those pertaining to the specified resource.")
+	cmd.Flags().BoolVar(&flags.IncludeChildren, "include-children", flags.IncludeChildren, "If true, also include the events of the objects owned by the --for resource, e.g. the replica sets and pods of a deployment, or the jobs and pods of a cron job, and the persistent volume claims used by those pods.")
 	cmd.Flags().StringSliceVar(&flags.FilterTypes, "types", flags.FilterTypes, "Output only events of given types.")
 	cmd.Flags().BoolVar(&flags.NoHeaders, "no-headers", flags.NoHeaders, "When using the default output format, don't print headers.")
+	cmd.Flags().BoolVar(&flags.Summary, "summary", flags.Summary, "Group events by kind of the involved object, type, reason and message, and print the number of occurrences and affected objects of each group.")
+	cmd.Flags().StringVar(&flags.Since, "since", flags.Since, "Only return events last observed after this time, given as a RFC3339 timestamp or a relative duration like 5s, 2m, or 3h.")
+	cmd.Flags().StringVar(&flags.Until, "until", flags.Until, "Only return events last observed before this time, given as a RFC3339 timestamp or a relative duration like 5s, 2m, or 3h.")
+	cmd.Flags().StringVar(&flags.OutputVersion, "output-version", flags.OutputVersion, "API version events are printed as with structured output formats. One of: v1, events.k8s.io/v1.")
 	cmdutil.AddChunkSizeFlag(cmd, &flags.ChunkSize)
 }
 
@@ -154,7 +206,11 @@ func (flags *EventsFlags) ToOptions() (*EventsOptions, error) {
 		AllNamespaces: flags.AllNamespaces,
 		Watch:         flags.Watch,
 		FilterTypes:   flags.FilterTypes,
+		NoHeaders:     flags.NoHeaders,
+		Summary:       flags.Summary,
 		IOStreams:     flags.IOStreams,
+
+		includeChildren: flags.IncludeChildren,
 	}
 	var err error
 	o.Namespace, _, err = flags.RESTClientGetter.ToRawKubeConfigLoader().Namespace()
@@ -175,6 +231,8 @@ func (flags *EventsFlags) ToOptions() (*EventsOptions, error) {
 		if !found {
 			return nil, fmt.Errorf("--for must be in resource/name form")
 		}
+	} else if flags.IncludeChildren {
+		return nil, fmt.Errorf("--include-children requires --for")
 	}
 
 	clientConfig, err := flags.RESTClientGetter.ToRESTConfig()
@@ -186,22 +244,61 @@ func (flags *EventsFlags) ToOptions() (*EventsOptions, error) {
 	if err != nil {
 		return nil, err
 	}
+	o.eventsV1 = servesEventsV1(o.client.Discovery())
 
 	if len(o.FilterTypes) > 0 {
 		o.FilterTypes = sets.List(sets.New[string](o.FilterTypes...))
 	}
 
+	now := time.Now()
+	if o.since, err = parseTimeBound(flags.Since, now); err != nil {
+		return nil, fmt.Errorf("invalid --since: %w", err)
+	}
+	if o.until, err = parseTimeBound(flags.Until, now); err != nil {
+		return nil, fmt.Errorf("invalid --until: %w", err)
+	}
+
+	var convert func(runtime.Object) (runtime.Object, error)
+	switch flags.OutputVersion {
+	case "", corev1.SchemeGroupVersion.String():
+	case eventsv1.SchemeGroupVersion.String():
+		convert = toEventsV1Object
+	default:
+		return nil, fmt.Errorf("--output-version must be one of v1 or events.k8s.io/v1")
+	}
+
 	var printer printers.ResourcePrinter
-	if flags.PrintFlags.OutputFormat != nil && len(*flags.PrintFlags.OutputFormat) > 0 {
+	outputFormat := ""
+	if flags.PrintFlags.OutputFormat != nil {
+		outputFormat = *flags.PrintFlags.OutputFormat
+	}
+	switch outputFormat {
+	case "", "wide":
+		if flags.Summary && len(outputFormat) > 0 {
+			return nil, fmt.Errorf("--summary cannot be used with --output")
+		}
+		eventPrinter := NewEventPrinter(flags.NoHeaders, flags.AllNamespaces)
+		eventPrinter.Wide = outputFormat == "wide"
+		printer = eventPrinter
+		// the table always shows the fields of both API versions
+		convert = nil
+	default:
+		if flags.Summary {
+			return nil, fmt.Errorf("--summary cannot be used with --output")
+		}
 		printer, err = flags.PrintFlags.ToPrinter()
 		if err != nil {
 			return nil, err
 		}
-	} else {
-		printer = NewEventPrinter(flags.NoHeaders, flags.AllNamespaces)
 	}
 
 	o.PrintObj = func(object runtime.Object, writer io.Writer) error {
+		if convert != nil {
+			var err error
+			if object, err = convert(object); err != nil {
+				return err
+			}
+		}
 		return printer.PrintObj(object, writer)
 	}
 
@@ -209,6 +306,14 @@ func (flags *EventsFlags) ToOptions() (*EventsOptions, error) {
 }
 
 func (o *EventsOptions) Validate() error {
+	if o.includeChildren && o.AllNamespaces {
+		return fmt.Errorf("--include-children cannot be used with --all-namespaces")
+	}
+
+	if !o.since.IsZero() && !o.until.IsZero() && o.until.Before(o.since) {
+		return fmt.Errorf("--until must not be before --since")
+	}
+
 	for _, val := range o.FilterTypes {
 		if !strings.EqualFold(val, "Normal") && !strings.EqualFold(val, "Warning") {
 			return fmt.Errorf("valid --types are Normal or Warning")
@@ -226,17 +331,30 @@ func (o *EventsOptions) Run() error {
 		namespace = ""
 	}
 	listOptions := metav1.ListOptions{Limit: cmdutil.DefaultChunkSize}
-	if o.forName != "" {
+	if o.forName != "" && o.includeChildren {
+		// events can only be selected by a single involved object, so
+		// filter the events of the related objects on the client
+		o.walker = &ownerWalker{client: o.client, namespace: namespace}
+		related, err := o.walker.relatedObjects(ctx, o.forGVK, o.forName)
+		if err != nil {
+			return err
+		}
+		o.relatedObjects = sets.New[objectKey](related...)
+		o.unrelatedObjects = sets.New[objectKey]()
+	} else if o.forName != "" {
+		involvedObject := "involvedObject"
+		if o.eventsV1 {
+			involvedObject = "regarding"
+		}
 		listOptions.FieldSelector = fields.AndSelectors(
-			fields.OneTermEqualSelector("involvedObject.kind", o.forGVK.Kind),
-			fields.OneTermEqualSelector("involvedObject.apiVersion", o.forGVK.GroupVersion().String()),
-			fields.OneTermEqualSelector("involvedObject.name", o.forName)).String()
+			fields.OneTermEqualSelector(involvedObject+".kind", o.forGVK.Kind),
+			fields.OneTermEqualSelector(involvedObject+".apiVersion", o.forGVK.GroupVersion().String()),
+			fields.OneTermEqualSelector(involvedObject+".name", o.forName)).String()
 	}
 	if o.Watch {
 		return o.runWatch(ctx, namespace, listOptions)
 	}
 
-	e := o.client.CoreV1().Events(namespace)
 	el := &corev1.EventList{
 		TypeMeta: metav1.TypeMeta{
 			Kind:       "EventList",
@@ -245,7 +363,17 @@ func (o *EventsOptions) Run() error {
 	}
 	err := runtimeresource.FollowContinue(&listOptions,
 		func(options metav1.ListOptions) (runtime.Object, error) {
-			newEvents, err := e.List(ctx, options)
+			if o.eventsV1 {
+				newEvents, err := o.client.EventsV1().Events(namespace).List(ctx, options)
+				if err != nil {
+					return nil, runtimeresource.EnhanceListError(err, options, "events")
+				}
+				for i := range newEvents.Items {
+					el.Items = append(el.Items, *fromEventsV1(&newEvents.Items[i]))
+				}
+				return newEvents, nil
+			}
+			newEvents, err := o.client.CoreV1().Events(namespace).List(ctx, options)
 			if err != nil {
 				return nil, runtimeresource.EnhanceListError(err, options, "events")
 			}
@@ -259,7 +387,12 @@ func (o *EventsOptions) Run() error {
 
 	var filteredEvents []corev1.Event
 	for _, e := range el.Items {
-		if !o.filteredEventType(e.Type) {
+		if !o.filteredEventType(e.Type) || !o.filteredEventTime(e) {
+			continue
+		}
+		if related, err := o.filteredInvolvedObject(ctx, e); err != nil {
+			return err
+		} else if !related {
 			continue
 		}
 		if e.GetObjectKind().GroupVersionKind().Empty() {
@@ -286,17 +419,36 @@ func (o *EventsOptions) Run() error {
 
 	sort.Sort(SortableEvents(el.Items))
 
+	if o.Summary {
+		aggregator := NewEventAggregator()
+		for _, e := range el.Items {
+			aggregator.Add(e)
+		}
+		if err := NewEventSummaryPrinter(o.NoHeaders).PrintSummaries(aggregator.Summaries(), w); err != nil {
+			return err
+		}
+		return w.Flush()
+	}
+
 	o.PrintObj(el, w)
 	w.Flush()
 	return nil
 }
 
 func (o *EventsOptions) runWatch(ctx context.Context, namespace string, listOptions metav1.ListOptions) error {
-	eventWatch, err := o.client.CoreV1().Events(namespace).Watch(ctx, listOptions)
+	var eventWatch watch.Interface
+	var err error
+	if o.eventsV1 {
+		eventWatch, err = o.client.EventsV1().Events(namespace).Watch(ctx, listOptions)
+	} else {
+		eventWatch, err = o.client.CoreV1().Events(namespace).Watch(ctx, listOptions)
+	}
 	if err != nil {
 		return err
 	}
 	w := printers.GetNewTabWriter(o.Out)
+	aggregator := NewEventAggregator()
+	summaryPrinter := NewEventSummaryPrinter(o.NoHeaders)
 
 	cctx, cancel := context.WithCancel(ctx)
 	defer cancel()
@@ -307,18 +459,36 @@ func (o *EventsOptions) runWatch(ctx context.Context, namespace string, listOpti
 				return false, nil
 			}
 
-			if ev, ok := e.Object.(*corev1.Event); !ok || !o.filteredEventType(ev.Type) {
+			var ev *corev1.Event
+			switch t := e.Object.(type) {
+			case *corev1.Event:
+				ev = t
+			case *eventsv1.Event:
+				ev = fromEventsV1(t)
+			}
+			if ev == nil || !o.filteredEventType(ev.Type) || !o.filteredEventTime(*ev) {
 				return false, nil
 			}
+			if related, err := o.filteredInvolvedObject(cctx, *ev); err != nil || !related {
+				return false, err
+			}
 
-			if e.Object.GetObjectKind().GroupVersionKind().Empty() {
-				e.Object.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{
+			if o.Summary {
+				// print the updated row of the group the event belongs to
+				if err := summaryPrinter.PrintSummaries([]*EventSummary{aggregator.Add(*ev)}, w); err != nil {
+					return false, err
+				}
+				return false, w.Flush()
+			}
+
+			if ev.GetObjectKind().GroupVersionKind().Empty() {
+				ev.SetGroupVersionKind(schema.GroupVersionKind{
 					Version: "v1",
 					Kind:    "Event",
 				})
 			}
 
-			o.PrintObj(e.Object, w)
+			o.PrintObj(ev, w)
 			w.Flush()
 			return false, nil
 		})
@@ -346,6 +516,66 @@ func (o *EventsOptions) filteredEventType(et string) bool {
 	return false
 }
 
+// filteredInvolvedObject checks whether the object the given event
+// pertains to is one of the objects related to the --for resource.
+// Objects which have not been seen before are related when their owner
+// chain leads to a related object, so the events of the pods a rollout
+// creates while watching are shown too.
+// If --include-children is not set, the events have already been
+// filtered on the server and this function allows all events.
+func (o *EventsOptions) filteredInvolvedObject(ctx context.Context, e corev1.Event) (bool, error) {
+	if o.relatedObjects == nil {
+		return true, nil
+	}
+	key := objectKey{kind: e.InvolvedObject.Kind, name: e.InvolvedObject.Name}
+	if o.relatedObjects.Has(key) {
+		return true, nil
+	}
+	if o.unrelatedObjects.Has(key) {
+		return false, nil
+	}
+	related, err := o.walker.resolve(ctx, key, o.relatedObjects)
+	if err != nil {
+		return false, err
+	}
+	if !related {
+		o.unrelatedObjects.Insert(key)
+	}
+	return related, nil
+}
+
+// filteredEventTime checks whether the given event has last been
+// observed within the --since and --until window.
+func (o *EventsOptions) filteredEventTime(e corev1.Event) bool {
+	t := eventTime(e)
+	if !o.since.IsZero() && t.Before(o.since) {
+		return false
+	}
+	if !o.until.IsZero() && t.After(o.until) {
+		return false
+	}
+	return true
+}
+
+// parseTimeBound parses s as either a RFC3339 timestamp or a duration
+// before now. An empty string results in a zero time.
+func parseTimeBound(s string, now time.Time) (time.Time, error) {
+	if len(s) == 0 {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		if d < 0 {
+			return time.Time{}, fmt.Errorf("duration %q must not be negative", s)
+		}
+		return now.Add(-d), nil
+	}
+	t, err := util.ParseRFC3339(s, metav1.Now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is neither a duration nor a RFC3339 timestamp", s)
+	}
+	return t.Time, nil
+}
+
 // SortableEvents implements sort.Interface for []api.Event by time
 type SortableEvents []corev1.Event
 
@@ -217,3 +217,69 @@ foo         18m (x3 over 28m)   Warning   ScalingReplicaSet   Deployment/bar   S
 		t.Errorf("expected\n%v\ngot\n%v", e, a)
 	}
 }
+
+func TestEventTimeWindow(t *testing.T) {
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	clientset, err := kubernetes.NewForConfig(cmdtesting.DefaultClientConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientset.CoreV1().RESTClient().(*restclient.RESTClient).Client = fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.ObjBody(codec, getFakeEvents())}, nil
+	})
+
+	printer := NewEventPrinter(false, true)
+
+	now := time.Now()
+	options := &EventsOptions{
+		AllNamespaces: true,
+		client:        clientset,
+		PrintObj: func(object runtime.Object, writer io.Writer) error {
+			return printer.PrintObj(object, writer)
+		},
+		IOStreams: streams,
+
+		since: now.Add(-19 * time.Minute),
+		until: now.Add(-16 * time.Minute),
+	}
+
+	err = options.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `NAMESPACE   LAST SEEN           TYPE      REASON              OBJECT           MESSAGE
+foo         18m (x3 over 28m)   Warning   ScalingReplicaSet   Deployment/bar   Scaled up replica set bar-002 from 0 to 1
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestParseTimeBound(t *testing.T) {
+	now := time.Date(2024, 8, 30, 6, 0, 0, 0, time.UTC)
+	tests := []struct {
+		value       string
+		expected    time.Time
+		expectedErr bool
+	}{
+		{value: ""},
+		{value: "1h", expected: now.Add(-time.Hour)},
+		{value: "2024-08-30T05:30:00Z", expected: time.Date(2024, 8, 30, 5, 30, 0, 0, time.UTC)},
+		{value: "-1h", expectedErr: true},
+		{value: "yesterday", expectedErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			actual, err := parseTimeBound(test.value, now)
+			if (err != nil) != test.expectedErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !actual.Equal(test.expected) {
+				t.Errorf("expected %v, got %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/discovery"
+	"k8s.io/klog/v2"
+)
+
+// servesEventsV1 returns whether the server serves events through the
+// events.k8s.io/v1 API. Events are read through the core/v1 API when the
+// discovery fails, as every server serves them.
+func servesEventsV1(client discovery.DiscoveryInterface) bool {
+	resources, err := client.ServerResourcesForGroupVersion(eventsv1.SchemeGroupVersion.String())
+	if err != nil {
+		klog.V(2).Infof("Reading events through the core/v1 API, the discovery of %s failed: %v", eventsv1.SchemeGroupVersion, err)
+		return false
+	}
+	for _, resource := range resources.APIResources {
+		if resource.Name == "events" {
+			return true
+		}
+	}
+	return false
+}
+
+// toEventsV1 converts an event read through the core/v1 API into its
+// events.k8s.io/v1 representation, the way the server converts them.
+// The fields only present in core/v1 are kept in the deprecated* fields
+// of events.k8s.io/v1, so events print the same whichever API they were
+// read through.
+func toEventsV1(e *corev1.Event) *eventsv1.Event {
+	out := &eventsv1.Event{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: eventsv1.SchemeGroupVersion.String(),
+			Kind:       "Event",
+		},
+		ObjectMeta:               *e.ObjectMeta.DeepCopy(),
+		EventTime:                e.EventTime,
+		ReportingController:      e.ReportingController,
+		ReportingInstance:        e.ReportingInstance,
+		Action:                   e.Action,
+		Reason:                   e.Reason,
+		Regarding:                e.InvolvedObject,
+		Related:                  e.Related.DeepCopy(),
+		Note:                     e.Message,
+		Type:                     e.Type,
+		DeprecatedSource:         e.Source,
+		DeprecatedFirstTimestamp: e.FirstTimestamp,
+		DeprecatedLastTimestamp:  e.LastTimestamp,
+		DeprecatedCount:          e.Count,
+	}
+	if e.Series != nil {
+		out.Series = &eventsv1.EventSeries{
+			Count:            e.Series.Count,
+			LastObservedTime: e.Series.LastObservedTime,
+		}
+	}
+	return out
+}
+
+// fromEventsV1 converts an event read through the events.k8s.io/v1 API
+// into its core/v1 representation, which the printers and filters of
+// events work on. It is the inverse of toEventsV1.
+func fromEventsV1(e *eventsv1.Event) *corev1.Event {
+	out := &corev1.Event{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Event",
+		},
+		ObjectMeta:          *e.ObjectMeta.DeepCopy(),
+		InvolvedObject:      e.Regarding,
+		Reason:              e.Reason,
+		Message:             e.Note,
+		Source:              e.DeprecatedSource,
+		FirstTimestamp:      e.DeprecatedFirstTimestamp,
+		LastTimestamp:       e.DeprecatedLastTimestamp,
+		Count:               e.DeprecatedCount,
+		Type:                e.Type,
+		EventTime:           e.EventTime,
+		Action:              e.Action,
+		Related:             e.Related.DeepCopy(),
+		ReportingController: e.ReportingController,
+		ReportingInstance:   e.ReportingInstance,
+	}
+	if e.Series != nil {
+		out.Series = &corev1.EventSeries{
+			Count:            e.Series.Count,
+			LastObservedTime: e.Series.LastObservedTime,
+		}
+	}
+	return out
+}
+
+// toEventsV1Object converts core/v1 events and event lists into their
+// events.k8s.io/v1 representation.
+func toEventsV1Object(obj runtime.Object) (runtime.Object, error) {
+	switch t := obj.(type) {
+	case *corev1.Event:
+		return toEventsV1(t), nil
+	case *corev1.EventList:
+		list := &eventsv1.EventList{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: eventsv1.SchemeGroupVersion.String(),
+				Kind:       "EventList",
+			},
+			ListMeta: t.ListMeta,
+			Items:    make([]eventsv1.Event, 0, len(t.Items)),
+		}
+		for i := range t.Items {
+			list.Items = append(list.Items, *toEventsV1(&t.Items[i]))
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("unknown event type %T", t)
+	}
+}
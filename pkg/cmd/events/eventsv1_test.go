@@ -0,0 +1,154 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	fakeexternal "k8s.io/client-go/kubernetes/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestToEventsV1Object(t *testing.T) {
+	list := getFakeEvents()
+	list.Items[0].Related = &corev1.ObjectReference{Kind: "ReplicaSet", Name: "bar-002"}
+	list.Items[0].Action = "Scale"
+	list.Items[1].ReportingController = ""
+	list.Items[1].Source = corev1.EventSource{Component: "deployment-controller", Host: "node-1"}
+
+	obj, err := toEventsV1Object(list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	converted, ok := obj.(*eventsv1.EventList)
+	if !ok {
+		t.Fatalf("expected an events.k8s.io/v1 EventList, got %T", obj)
+	}
+	if converted.APIVersion != "events.k8s.io/v1" || converted.Kind != "EventList" {
+		t.Errorf("unexpected type meta: %#v", converted.TypeMeta)
+	}
+	if len(converted.Items) != len(list.Items) {
+		t.Fatalf("expected %d events, got %d", len(list.Items), len(converted.Items))
+	}
+
+	first := converted.Items[0]
+	if first.Note != list.Items[0].Message || first.Regarding != list.Items[0].InvolvedObject {
+		t.Errorf("unexpected note or regarding object: %#v", first)
+	}
+	if first.Related == nil || first.Related.Name != "bar-002" || first.Action != "Scale" {
+		t.Errorf("unexpected related object or action: %#v", first)
+	}
+	if first.Series == nil || first.Series.Count != 3 {
+		t.Errorf("unexpected series: %#v", first.Series)
+	}
+
+	second := converted.Items[1]
+	if second.ReportingController != "" || second.DeprecatedSource != list.Items[1].Source {
+		t.Errorf("expected the source to be kept as the deprecated source only, got %q and %#v", second.ReportingController, second.DeprecatedSource)
+	}
+
+	for i := range converted.Items {
+		if roundTripped := fromEventsV1(&converted.Items[i]); !apiequality.Semantic.DeepEqual(roundTripped, withEventTypeMeta(list.Items[i])) {
+			t.Errorf("expected events to convert back unchanged, got %#v", roundTripped)
+		}
+	}
+}
+
+func withEventTypeMeta(e corev1.Event) *corev1.Event {
+	e.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Event"}
+	return &e
+}
+
+func TestEventsReadThroughEventsV1(t *testing.T) {
+	var objects []runtime.Object
+	for i := range getFakeEvents().Items {
+		objects = append(objects, toEventsV1(&getFakeEvents().Items[i]))
+	}
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	printer := NewEventPrinter(false, true)
+	options := &EventsOptions{
+		AllNamespaces: true,
+		client:        fakeexternal.NewSimpleClientset(objects...),
+		eventsV1:      true,
+		PrintObj: func(object runtime.Object, writer io.Writer) error {
+			return printer.PrintObj(object, writer)
+		},
+		IOStreams: streams,
+	}
+
+	if err := options.Run(); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `NAMESPACE   LAST SEEN           TYPE      REASON              OBJECT           MESSAGE
+foo         20m (x3 over 30m)   Normal    ScalingReplicaSet   Deployment/bar   Scaled up replica set bar-002 from 0 to 1
+foo         18m (x3 over 28m)   Warning   ScalingReplicaSet   Deployment/bar   Scaled up replica set bar-002 from 0 to 1
+otherfoo    15m (x3 over 25m)   Normal    ScalingReplicaSet   Deployment/bar   Scaled up replica set bar-002 from 0 to 1
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestServesEventsV1(t *testing.T) {
+	tests := []struct {
+		name      string
+		resources []*metav1.APIResourceList
+		err       error
+		expected  bool
+	}{
+		{
+			name: "served",
+			resources: []*metav1.APIResourceList{{
+				GroupVersion: "events.k8s.io/v1",
+				APIResources: []metav1.APIResource{{Name: "events", Kind: "Event", Namespaced: true}},
+			}},
+			expected: true,
+		},
+		{
+			name:     "not served",
+			expected: false,
+		},
+		{
+			name:     "discovery error",
+			err:      errors.New("the server is currently unable to handle the request"),
+			expected: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client := fakeexternal.NewSimpleClientset()
+			client.Resources = test.resources
+			if test.err != nil {
+				client.PrependReactor("get", "resource", func(action clienttesting.Action) (bool, runtime.Object, error) {
+					return true, nil, test.err
+				})
+			}
+			if served := servesEventsV1(client.Discovery()); served != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, served)
+			}
+		})
+	}
+}
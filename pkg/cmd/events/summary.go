@@ -0,0 +1,263 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+var numberRegexp = regexp.MustCompile(`[0-9]+`)
+
+// summaryKey identifies a group of similar events.
+type summaryKey struct {
+	kind            string
+	eventType       string
+	reason          string
+	messageTemplate string
+}
+
+// EventSummary aggregates all events sharing the kind of the involved
+// object, the type, the reason and the message template.
+type EventSummary struct {
+	Kind   string
+	Type   string
+	Reason string
+	// Message is the message of the most recent event of the group.
+	Message   string
+	Count     int32
+	FirstSeen time.Time
+	LastSeen  time.Time
+	// Objects are the involved objects, as namespace/name.
+	Objects sets.Set[string]
+}
+
+type eventContribution struct {
+	key   summaryKey
+	count int32
+}
+
+// EventAggregator groups events into EventSummaries. Events are tracked by
+// UID, so that an updated event replaces its previous contribution instead
+// of being counted twice.
+type EventAggregator struct {
+	groups map[summaryKey]*EventSummary
+	events map[types.UID]eventContribution
+}
+
+// NewEventAggregator returns an empty EventAggregator.
+func NewEventAggregator() *EventAggregator {
+	return &EventAggregator{
+		groups: map[summaryKey]*EventSummary{},
+		events: map[types.UID]eventContribution{},
+	}
+}
+
+// Add adds e to its group and returns the updated summary of the group.
+func (a *EventAggregator) Add(e corev1.Event) *EventSummary {
+	key := summaryKey{
+		kind:            e.InvolvedObject.Kind,
+		eventType:       e.Type,
+		reason:          e.Reason,
+		messageTemplate: messageTemplate(e),
+	}
+	summary, ok := a.groups[key]
+	if !ok {
+		summary = &EventSummary{
+			Kind:    e.InvolvedObject.Kind,
+			Type:    e.Type,
+			Reason:  e.Reason,
+			Objects: sets.New[string](),
+		}
+		a.groups[key] = summary
+	}
+
+	count := eventCount(e)
+	if len(e.UID) > 0 {
+		if previous, ok := a.events[e.UID]; ok {
+			group := a.groups[previous.key]
+			group.Count -= previous.count
+			if group.Count <= 0 && previous.key != key {
+				// the event moved to another group, leaving this one empty
+				delete(a.groups, previous.key)
+			}
+		}
+		a.events[e.UID] = eventContribution{key: key, count: count}
+	}
+	summary.Count += count
+
+	first := eventFirstTime(e)
+	if summary.FirstSeen.IsZero() || (!first.IsZero() && first.Before(summary.FirstSeen)) {
+		summary.FirstSeen = first
+	}
+	if last := eventTime(e); !last.Before(summary.LastSeen) {
+		summary.LastSeen = last
+		summary.Message = strings.TrimSpace(e.Message)
+	}
+	summary.Objects.Insert(e.InvolvedObject.Namespace + "/" + e.InvolvedObject.Name)
+	return summary
+}
+
+// Summaries returns all groups, the most frequent first.
+func (a *EventAggregator) Summaries() []*EventSummary {
+	summaries := make([]*EventSummary, 0, len(a.groups))
+	for _, s := range a.groups {
+		summaries = append(summaries, s)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		if !summaries[i].LastSeen.Equal(summaries[j].LastSeen) {
+			return summaries[i].LastSeen.After(summaries[j].LastSeen)
+		}
+		return summaries[i].Reason < summaries[j].Reason
+	})
+	return summaries
+}
+
+// messageTemplate returns the message of e with the name of the involved
+// object and all numbers masked, so that e.g. scheduling failures of
+// different pods end up in the same group.
+func messageTemplate(e corev1.Event) string {
+	message := strings.TrimSpace(e.Message)
+	if len(e.InvolvedObject.Name) > 0 {
+		message = maskObjectName(message, e.InvolvedObject.Namespace, e.InvolvedObject.Name)
+	}
+	return numberRegexp.ReplaceAllString(message, "#")
+}
+
+// maskObjectName replaces name, or namespace/name, with "*" in message where
+// it is a whole token, so that the name of the pod web-1 is not masked in
+// web-10.
+func maskObjectName(message, namespace, name string) string {
+	pattern := regexp.QuoteMeta(name)
+	if len(namespace) > 0 {
+		pattern = regexp.QuoteMeta(namespace+"/"+name) + "|" + pattern
+	}
+	var masked strings.Builder
+	last := 0
+	for _, match := range regexp.MustCompile(pattern).FindAllStringIndex(message, -1) {
+		if isNameChar(message, match[0]-1) || isNameChar(message, match[1]) {
+			continue
+		}
+		masked.WriteString(message[last:match[0]])
+		masked.WriteString("*")
+		last = match[1]
+	}
+	masked.WriteString(message[last:])
+	return masked.String()
+}
+
+// isNameChar returns whether the byte at i of s can be part of an object
+// name. A dot is one only when a letter or a digit follows it, so that a name at
+// the end of a sentence is still a whole token.
+func isNameChar(s string, i int) bool {
+	if i < 0 || i >= len(s) {
+		return false
+	}
+	switch c := s[i]; {
+	case c == '-', c == '_', isAlphanumeric(c):
+		return true
+	case c == '.':
+		return i+1 < len(s) && isAlphanumeric(s[i+1])
+	}
+	return false
+}
+
+func isAlphanumeric(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+}
+
+// eventCount returns how often e has been observed.
+func eventCount(e corev1.Event) int32 {
+	if e.Series != nil && e.Series.Count > 0 {
+		return e.Series.Count
+	}
+	if e.Count > 0 {
+		return e.Count
+	}
+	return 1
+}
+
+// eventFirstTime returns the time e has first been observed.
+func eventFirstTime(e corev1.Event) time.Time {
+	if !e.EventTime.IsZero() {
+		return e.EventTime.Time
+	}
+	return e.FirstTimestamp.Time
+}
+
+// EventSummaryPrinter prints EventSummaries as a table.
+type EventSummaryPrinter struct {
+	NoHeaders bool
+
+	headersPrinted bool
+}
+
+// NewEventSummaryPrinter returns an EventSummaryPrinter.
+func NewEventSummaryPrinter(noHeaders bool) *EventSummaryPrinter {
+	return &EventSummaryPrinter{
+		NoHeaders: noHeaders,
+	}
+}
+
+// PrintSummaries prints one row per summary.
+func (p *EventSummaryPrinter) PrintSummaries(summaries []*EventSummary, w io.Writer) error {
+	if !p.NoHeaders && !p.headersPrinted {
+		if _, err := fmt.Fprintf(w, "COUNT\tTYPE\tREASON\tKIND\tOBJECTS\tFIRST SEEN\tLAST SEEN\tMESSAGE\n"); err != nil {
+			return err
+		}
+		p.headersPrinted = true
+	}
+	for _, s := range summaries {
+		_, err := fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%d\t%s\t%s\t%s\n",
+			s.Count,
+			printers.EscapeTerminal(s.Type),
+			printers.EscapeTerminal(s.Reason),
+			printers.EscapeTerminal(s.Kind),
+			s.Objects.Len(),
+			translateTimeSince(s.FirstSeen),
+			translateTimeSince(s.LastSeen),
+			printers.EscapeTerminal(s.Message),
+		)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// translateTimeSince returns the elapsed time since t in
+// human-readable approximation.
+func translateTimeSince(t time.Time) string {
+	if t.IsZero() {
+		return "<unknown>"
+	}
+
+	return duration.HumanDuration(time.Since(t))
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	restclient "k8s.io/client-go/rest"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+)
+
+func TestEventSummary(t *testing.T) {
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	clientset, err := kubernetes.NewForConfig(cmdtesting.DefaultClientConfig())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clientset.CoreV1().RESTClient().(*restclient.RESTClient).Client = fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+		return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.ObjBody(codec, getFakeEvents())}, nil
+	})
+
+	options := &EventsOptions{
+		AllNamespaces: true,
+		Summary:       true,
+		client:        clientset,
+		IOStreams:     streams,
+	}
+
+	err = options.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `COUNT   TYPE      REASON              KIND         OBJECTS   FIRST SEEN   LAST SEEN   MESSAGE
+6       Normal    ScalingReplicaSet   Deployment   2         30m          15m         Scaled up replica set bar-002 from 0 to 1
+3       Warning   ScalingReplicaSet   Deployment   1         28m          18m         Scaled up replica set bar-002 from 0 to 1
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestEventAggregator(t *testing.T) {
+	failedScheduling := func(uid, pod string, count int32) corev1.Event {
+		return corev1.Event{
+			ObjectMeta: metav1.ObjectMeta{UID: types.UID(uid), Namespace: "foo"},
+			InvolvedObject: corev1.ObjectReference{
+				Kind:      "Pod",
+				Name:      pod,
+				Namespace: "foo",
+			},
+			Type:          corev1.EventTypeWarning,
+			Reason:        "FailedScheduling",
+			Message:       fmt.Sprintf("0/%d nodes are available: pod %s has unbound immediate PersistentVolumeClaims", count+2, pod),
+			Count:         count,
+			LastTimestamp: metav1.NewTime(time.Now().Add(-time.Duration(count) * time.Minute)),
+		}
+	}
+
+	aggregator := NewEventAggregator()
+	aggregator.Add(failedScheduling("1", "web-1", 3))
+	aggregator.Add(failedScheduling("2", "web-2", 5))
+	// an update of an already seen event replaces its previous count
+	aggregator.Add(failedScheduling("1", "web-1", 4))
+	aggregator.Add(corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{UID: "3", Namespace: "foo"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "foo"},
+		Type:           corev1.EventTypeNormal,
+		Reason:         "Pulled",
+		Message:        "Successfully pulled image",
+	})
+
+	summaries := aggregator.Summaries()
+	if len(summaries) != 2 {
+		t.Fatalf("expected 2 groups, got %d: %#v", len(summaries), summaries)
+	}
+	if s := summaries[0]; s.Reason != "FailedScheduling" || s.Count != 9 || s.Objects.Len() != 2 {
+		t.Errorf("unexpected first group: %#v", s)
+	}
+	if s := summaries[1]; s.Reason != "Pulled" || s.Count != 1 || s.Objects.Len() != 1 {
+		t.Errorf("unexpected second group: %#v", s)
+	}
+}
+
+func TestEventAggregatorMovedEvent(t *testing.T) {
+	backOff := func(message string) corev1.Event {
+		return corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{UID: "1", Namespace: "foo"},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "foo"},
+			Type:           corev1.EventTypeWarning,
+			Reason:         "BackOff",
+			Message:        message,
+		}
+	}
+
+	aggregator := NewEventAggregator()
+	aggregator.Add(backOff("Back-off pulling image"))
+	// the updated event moves to the group of its new message
+	aggregator.Add(backOff("Back-off restarting failed container"))
+
+	summaries := aggregator.Summaries()
+	if len(summaries) != 1 {
+		t.Fatalf("expected 1 group, got %d: %#v", len(summaries), summaries)
+	}
+	if s := summaries[0]; s.Message != "Back-off restarting failed container" || s.Count != 1 {
+		t.Errorf("unexpected group: %#v", s)
+	}
+}
+
+func TestMessageTemplate(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected string
+	}{
+		{message: "Created container web-1", expected: "Created container *"},
+		{message: "Started pod web-1.", expected: "Started pod *."},
+		{message: "Pod foo/web-1 is not ready", expected: "Pod * is not ready"},
+		{message: "Scaled up web-10 and web-1.example", expected: "Scaled up web-# and web-#.example"},
+		{message: "Image myweb-1:v2 pulled", expected: "Image myweb-#:v# pulled"},
+	}
+	for _, test := range tests {
+		t.Run(test.message, func(t *testing.T) {
+			e := corev1.Event{
+				InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "foo"},
+				Message:        test.message,
+			}
+			if template := messageTemplate(e); template != test.expected {
+				t.Errorf("expected %q, got %q", test.expected, template)
+			}
+		})
+	}
+}
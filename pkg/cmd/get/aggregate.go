@@ -0,0 +1,338 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// Functions aggregating the values of a column for each group.
+const (
+	aggregateSum = "sum"
+	aggregateMin = "min"
+	aggregateMax = "max"
+)
+
+// aggregation is a function aggregating the values of a column, or of a
+// JSONPath expression, for each group.
+type aggregation struct {
+	function string
+	key      string
+}
+
+// parseAggregations parses aggregations written as FUNCTION:COLUMN.
+func parseAggregations(specs []string) ([]aggregation, error) {
+	var aggregations []aggregation
+	for _, spec := range specs {
+		function, key, found := strings.Cut(spec, ":")
+		if !found || len(key) == 0 {
+			return nil, fmt.Errorf("invalid aggregation %q, expected FUNCTION:COLUMN", spec)
+		}
+		switch function {
+		case aggregateSum, aggregateMin, aggregateMax:
+		default:
+			return nil, fmt.Errorf("invalid aggregation %q, the function must be one of %s, %s or %s", spec, aggregateSum, aggregateMin, aggregateMax)
+		}
+		aggregations = append(aggregations, aggregation{function: function, key: key})
+	}
+	return aggregations, nil
+}
+
+// aggregatedRow is a listed object, with the cells of its row when the
+// server printed it in a table.
+type aggregatedRow struct {
+	cells  map[string]interface{}
+	object runtime.Object
+}
+
+// aggregatedRows returns the rows of a table, or the objects of a list.
+func aggregatedRows(obj runtime.Object) ([]aggregatedRow, error) {
+	if decoded, err := decodeIntoTable(obj); err == nil {
+		table := decoded.(*metav1.Table)
+		var rows []aggregatedRow
+		for _, tableRow := range table.Rows {
+			rows = append(rows, aggregatedRow{cells: tableRowCells(table, tableRow), object: tableRow.Object.Object})
+		}
+		return rows, nil
+	}
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return nil, err
+		}
+		var rows []aggregatedRow
+		for _, item := range items {
+			rows = append(rows, aggregatedRow{object: item})
+		}
+		return rows, nil
+	}
+	return []aggregatedRow{{object: obj}}, nil
+}
+
+// aggregationGroup is a group of objects with the same values of the keys.
+type aggregationGroup struct {
+	keys   []string
+	count  int64
+	values []*apiresource.Quantity
+}
+
+// aggregator groups the listed objects by columns, or by JSONPath
+// expressions, counting them and aggregating the values of other columns.
+type aggregator struct {
+	groupBy      []string
+	aggregations []aggregation
+	// columns are the custom columns, by lowercase header.
+	columns map[string]Column
+	parsers map[string]*jsonpath.JSONPath
+	groups  map[string]*aggregationGroup
+}
+
+func newAggregator(groupBy []string, aggregations []aggregation, columns []Column) *aggregator {
+	a := &aggregator{
+		groupBy:      groupBy,
+		aggregations: aggregations,
+		columns:      map[string]Column{},
+		parsers:      map[string]*jsonpath.JSONPath{},
+		groups:       map[string]*aggregationGroup{},
+	}
+	for _, column := range columns {
+		a.columns[strings.ToLower(column.Header)] = column
+	}
+	return a
+}
+
+// add adds a row to its group.
+func (a *aggregator) add(row aggregatedRow) error {
+	keys := make([]string, len(a.groupBy))
+	for i, key := range a.groupBy {
+		values, err := a.values(row, key)
+		if err != nil {
+			return err
+		}
+		keys[i] = strings.Join(values, ",")
+		if len(keys[i]) == 0 {
+			keys[i] = "<none>"
+		}
+	}
+
+	id := strings.Join(keys, "\x00")
+	group, found := a.groups[id]
+	if !found {
+		group = &aggregationGroup{keys: keys, values: make([]*apiresource.Quantity, len(a.aggregations))}
+		a.groups[id] = group
+	}
+	group.count++
+
+	for i, agg := range a.aggregations {
+		values, err := a.values(row, agg.key)
+		if err != nil {
+			return err
+		}
+		for _, value := range values {
+			q, err := parseAggregatedValue(value)
+			if err != nil {
+				return fmt.Errorf("cannot %s %q: %q is not a number or a quantity", agg.function, agg.key, value)
+			}
+			switch current := group.values[i]; {
+			case current == nil:
+				group.values[i] = &q
+			case agg.function == aggregateSum:
+				current.Add(q)
+			case agg.function == aggregateMin && q.Cmp(*current) < 0,
+				agg.function == aggregateMax && q.Cmp(*current) > 0:
+				group.values[i] = &q
+			}
+		}
+	}
+	return nil
+}
+
+// parseAggregatedValue parses a value as a quantity. The server prints
+// some numbers with details after them, like the restarts of a pod printed as
+// "3 (5m ago)", so only the leading field is parsed.
+func parseAggregatedValue(value string) (apiresource.Quantity, error) {
+	if fields := strings.Fields(value); len(fields) > 1 {
+		value = fields[0]
+	}
+	return apiresource.ParseQuantity(value)
+}
+
+// values returns the values of a key of a row: the value of a custom
+// column, or the cell of the column of the table printed by the server, or
+// the results of the key as a JSONPath expression.
+func (a *aggregator) values(row aggregatedRow, key string) ([]string, error) {
+	expression := key
+	column, isColumn := a.columns[strings.ToLower(key)]
+	cell, isCell := row.cells[strings.ToLower(key)]
+	switch {
+	case isColumn && column.expression != nil:
+		value := column.expression.evaluate(columnInput{object: row.object, cells: row.cells})
+		if len(value) == 0 || value == "<none>" {
+			return nil, nil
+		}
+		return []string{value}, nil
+	case isColumn:
+		expression = column.FieldSpec
+	case isCell:
+		if cell == nil || cell == "" || cell == "<none>" {
+			return nil, nil
+		}
+		return []string{fmt.Sprint(cell)}, nil
+	case !strings.HasPrefix(key, ".") && !strings.HasPrefix(key, "{"):
+		return nil, fmt.Errorf("unknown column %q, expected a column of the table or a JSONPath expression like .spec.nodeName", key)
+	}
+	parser, found := a.parsers[expression]
+	if !found {
+		field, err := RelaxedJSONPathExpression(expression)
+		if err != nil {
+			return nil, err
+		}
+		parser = jsonpath.New("aggregation").AllowMissingKeys(true)
+		if err := parser.Parse(field); err != nil {
+			return nil, err
+		}
+		a.parsers[expression] = parser
+	}
+
+	if row.object == nil {
+		return nil, nil
+	}
+	results, err := findJSONPathResults(parser, row.object)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			if !value.IsValid() || !value.CanInterface() || value.Interface() == nil {
+				continue
+			}
+			if s := fmt.Sprint(value.Interface()); len(s) > 0 {
+				values = append(values, s)
+			}
+		}
+	}
+	return values, nil
+}
+
+// table returns a table with a row for each group, sorted by the keys.
+func (a *aggregator) table() *metav1.Table {
+	table := &metav1.Table{}
+	for _, key := range a.groupBy {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: key, Type: "string"})
+	}
+	table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: "Count", Type: "integer"})
+	for _, agg := range a.aggregations {
+		table.ColumnDefinitions = append(table.ColumnDefinitions, metav1.TableColumnDefinition{Name: fmt.Sprintf("%s(%s)", agg.function, agg.key), Type: "string"})
+	}
+
+	groups := make([]*aggregationGroup, 0, len(a.groups))
+	for _, group := range a.groups {
+		groups = append(groups, group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		for k := range groups[i].keys {
+			if groups[i].keys[k] != groups[j].keys[k] {
+				return groups[i].keys[k] < groups[j].keys[k]
+			}
+		}
+		return false
+	})
+
+	for _, group := range groups {
+		cells := make([]interface{}, 0, len(table.ColumnDefinitions))
+		for _, key := range group.keys {
+			cells = append(cells, key)
+		}
+		cells = append(cells, group.count)
+		for _, value := range group.values {
+			if value == nil {
+				cells = append(cells, "<none>")
+				continue
+			}
+			cells = append(cells, value.String())
+		}
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
+	}
+	if len(a.groupBy) == 0 && len(table.Rows) == 0 {
+		// counting no objects
+		cells := []interface{}{int64(0)}
+		for range a.aggregations {
+			cells = append(cells, "<none>")
+		}
+		table.Rows = append(table.Rows, metav1.TableRow{Cells: cells})
+	}
+	return table
+}
+
+// printAggregated prints a table of the groups of the listed objects,
+// instead of the objects.
+func (o *GetOptions) printAggregated(r *resource.Result) error {
+	var errs []error
+	infos, err := r.Infos()
+	if err != nil {
+		errs = append(errs, err)
+	}
+
+	aggregations, err := parseAggregations(o.Aggregate)
+	if err != nil {
+		return err
+	}
+	// the keys can be the headers of custom columns
+	var columns []Column
+	printFlags := o.PrintFlags.Copy()
+	if printer, err := printFlags.ToPrinter(); err == nil {
+		if customColumns, ok := printer.(*CustomColumnsPrinter); ok {
+			columns = customColumns.Columns
+		}
+	}
+
+	a := newAggregator(o.GroupBy, aggregations, columns)
+	for _, info := range infos {
+		rows, err := aggregatedRows(info.Object)
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			if len(o.where) > 0 && (row.object == nil || !matchesWhere(o.where, row.object)) {
+				continue
+			}
+			if err := a.add(row); err != nil {
+				return err
+			}
+		}
+	}
+
+	w := printers.GetNewTabWriter(o.Out)
+	printer := printers.NewTablePrinter(printers.PrintOptions{NoHeaders: o.NoHeaders})
+	if err := printer.PrintObj(a.table(), w); err != nil {
+		errs = append(errs, err)
+	}
+	w.Flush()
+	return utilerrors.Reduce(utilerrors.Flatten(utilerrors.NewAggregate(errs)))
+}
@@ -0,0 +1,321 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+// scheduledOn schedules a pod on the given node, with a container requesting
+// each of the given cpu.
+func scheduledOn(node string, cpu ...string) func(pod *corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Spec.NodeName = node
+		for i, request := range cpu {
+			pod.Spec.Containers = append(pod.Spec.Containers, corev1.Container{
+				Name:      fmt.Sprintf("c%d", i),
+				Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: apiresource.MustParse(request)}},
+			})
+		}
+	}
+}
+
+// podTableRows returns the rows to aggregate of a table of the given pods, as
+// printed by the server, with the given cells in the Restarts column.
+func podTableRows(t *testing.T, pods []*unstructured.Unstructured, restarts []interface{}) []aggregatedRow {
+	table := &metav1.Table{ColumnDefinitions: podColumns}
+	for i, pod := range pods {
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells:  []interface{}{pod.GetName(), "1/1", "Running", restarts[i], "5m", "<none>", "<none>", "<none>", "<none>"},
+			Object: runtime.RawExtension{Object: pod},
+		})
+	}
+	rows, err := aggregatedRows(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rows
+}
+
+func TestAggregator(t *testing.T) {
+	aggregations, err := parseAggregations([]string{"sum:.spec.containers[*].resources.requests.cpu", "max:Restarts", "min:.spec.containers[*].resources.requests.cpu"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := newAggregator([]string{".spec.nodeName"}, aggregations, nil)
+	pods := []*unstructured.Unstructured{
+		testPod(t, "a", scheduledOn("node1", "100m", "1")),
+		testPod(t, "b", scheduledOn("node2", "250m")),
+		testPod(t, "c", scheduledOn("node1", "500m")),
+		testPod(t, "d", scheduledOn("")),
+	}
+	for _, row := range podTableRows(t, pods, []interface{}{"2 (5m ago)", "1 (3h ago)", int64(1), int64(0)}) {
+		if err := a.add(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	table := a.table()
+	var columns []string
+	for _, column := range table.ColumnDefinitions {
+		columns = append(columns, column.Name)
+	}
+	expectedColumns := []string{".spec.nodeName", "Count", "sum(.spec.containers[*].resources.requests.cpu)", "max(Restarts)", "min(.spec.containers[*].resources.requests.cpu)"}
+	if !reflect.DeepEqual(expectedColumns, columns) {
+		t.Errorf("unexpected columns %q", columns)
+	}
+	var rows [][]interface{}
+	for _, row := range table.Rows {
+		rows = append(rows, row.Cells)
+	}
+	expectedRows := [][]interface{}{
+		{"<none>", int64(1), "<none>", "0", "<none>"},
+		{"node1", int64(2), "1600m", "2", "100m"},
+		{"node2", int64(1), "250m", "1", "250m"},
+	}
+	if !reflect.DeepEqual(expectedRows, rows) {
+		t.Errorf("unexpected rows %v", rows)
+	}
+}
+
+func TestAggregatorCustomColumns(t *testing.T) {
+	a := newAggregator([]string{"NODE"}, nil, []Column{{Header: "NODE", FieldSpec: "{.spec.nodeName}"}})
+	if err := a.add(aggregatedRow{object: testPod(t, "a", scheduledOn("node1"))}); err != nil {
+		t.Fatal(err)
+	}
+	if rows := a.table().Rows; len(rows) != 1 || !reflect.DeepEqual([]interface{}{"node1", int64(1)}, rows[0].Cells) {
+		t.Errorf("unexpected rows %v", rows)
+	}
+}
+
+func TestAggregatorErrors(t *testing.T) {
+	rows := podTableRows(t, []*unstructured.Unstructured{testPod(t, "a", scheduledOn("node1"))}, []interface{}{int64(0)})
+	a := newAggregator([]string{"zone"}, nil, nil)
+	if err := a.add(rows[0]); err == nil {
+		t.Errorf("expected an error for an unknown column")
+	}
+
+	a = newAggregator(nil, []aggregation{{function: aggregateSum, key: "name"}}, nil)
+	if err := a.add(rows[0]); err == nil {
+		t.Errorf("expected an error for a column which is not a quantity")
+	}
+
+	for _, spec := range []string{"sum", "sum:", "avg:.spec.replicas"} {
+		if _, err := parseAggregations([]string{spec}); err == nil {
+			t.Errorf("expected an error for the aggregation %q", spec)
+		}
+	}
+}
+
+func TestGetGroupByTable(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Resp:                 &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: podTableObjBody(codec, pods.Items...)},
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("group-by", "ready")
+	cmd.Flags().Set("aggregate", "sum:restarts")
+	cmd.Run(cmd, []string{"pods"})
+
+	expected := `READY   COUNT   SUM(RESTARTS)
+0/0     2       0
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestGetAggregateRestarts(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	table := &metav1.Table{
+		TypeMeta:          metav1.TypeMeta{APIVersion: "meta.k8s.io/v1beta1", Kind: "Table"},
+		ColumnDefinitions: podColumns,
+	}
+	for i, restarts := range []interface{}{"3 (5m ago)", int64(0)} {
+		b := bytes.NewBuffer(nil)
+		codec.Encode(&pods.Items[i], b)
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Object: runtime.RawExtension{Raw: b.Bytes()},
+			Cells:  []interface{}{pods.Items[i].Name, "1/1", "Running", restarts, "10m", "<none>", "<none>", "<none>", "<none>"},
+		})
+	}
+	data, err := json.Marshal(table)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Resp:                 &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.BytesBody(data)},
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("count", "true")
+	cmd.Flags().Set("aggregate", "max:restarts")
+	cmd.Run(cmd, []string{"pods"})
+
+	expected := `COUNT   MAX(RESTARTS)
+2       3
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestGetGroupByFieldTable(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+	scheduledOn("node1", "100m", "250m")(&pods.Items[0])
+	scheduledOn("node2", "500m")(&pods.Items[1])
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Resp:                 &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: podTableObjBody(codec, pods.Items...)},
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("group-by", ".spec.nodeName")
+	cmd.Flags().Set("aggregate", "sum:.spec.containers[*].resources.requests.cpu")
+	cmd.Run(cmd, []string{"pods"})
+
+	expected := `.SPEC.NODENAME   COUNT   SUM(.SPEC.CONTAINERS[*].RESOURCES.REQUESTS.CPU)
+node1            1       350m
+node2            1       500m
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestGetCountCustomColumns(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Resp:                 &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.ObjBody(codec, pods)},
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("output", "custom-columns=NAME:.metadata.name,NS:.metadata.namespace")
+	cmd.Flags().Set("group-by", "NS")
+	cmd.Flags().Set("count", "true")
+	cmd.Run(cmd, []string{"pods"})
+
+	expected := `NS     COUNT
+test   2
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestValidateGroupBy(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(o *GetOptions)
+		wantErr string
+	}{
+		{
+			name:  "group by",
+			setup: func(o *GetOptions) { o.GroupBy, o.Aggregate = []string{"status"}, []string{"max:restarts"} },
+		},
+		{
+			name:  "custom columns",
+			setup: func(o *GetOptions) { o.Count, *o.PrintFlags.OutputFormat = true, "custom-columns=NAME:.metadata.name" },
+		},
+		{
+			name:    "aggregate without group by",
+			setup:   func(o *GetOptions) { o.Aggregate = []string{"max:restarts"} },
+			wantErr: "--aggregate option can only be used with --group-by or --count",
+		},
+		{
+			name:    "watch",
+			setup:   func(o *GetOptions) { o.Count, o.Watch = true, true },
+			wantErr: "--group-by and --count options cannot be used with --watch or --watch-only",
+		},
+		{
+			name:    "yaml",
+			setup:   func(o *GetOptions) { o.GroupBy, *o.PrintFlags.OutputFormat = []string{"status"}, "yaml" },
+			wantErr: "--group-by and --count options cannot be used with yaml printer",
+		},
+		{
+			name:    "invalid aggregation",
+			setup:   func(o *GetOptions) { o.Count, o.Aggregate = true, []string{"avg:restarts"} },
+			wantErr: `invalid aggregation "avg:restarts", the function must be one of sum, min or max`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewGetOptions("kubectl", genericiooptions.NewTestIOStreamsDiscard())
+			tt.setup(o)
+			err := o.Validate()
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(tt.wantErr) > 0 && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,231 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// ignoredChanges are the fields changing on every update, which are left out
+// of the changes unless they are watched explicitly.
+var ignoredChanges = map[string]bool{
+	".metadata.resourceVersion": true,
+	".metadata.managedFields":   true,
+}
+
+// simpleKey matches the keys which can be written as .KEY in a field path.
+var simpleKey = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*$`)
+
+// fieldChange is a field whose value changed between two versions of an
+// object. A nil value means that the field was not set.
+type fieldChange struct {
+	path     string
+	old, new interface{}
+}
+
+func (c fieldChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", c.path, formatChangedValue(c.old), formatChangedValue(c.new))
+}
+
+func formatChangedValue(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// changeTracker remembers the last version of the watched objects, to
+// report the fields changed by each modification.
+type changeTracker struct {
+	// fields limits the changes to these fields, given as paths.
+	fields  [][]string
+	objects map[string]*unstructured.Unstructured
+}
+
+// newChangeTracker returns a changeTracker reporting the changes of the
+// given fields, written like .status or .spec.replicas, or of all the fields
+// when none is given.
+func newChangeTracker(fields []string) (*changeTracker, error) {
+	t := &changeTracker{objects: map[string]*unstructured.Unstructured{}}
+	for _, field := range fields {
+		path := strings.Split(strings.TrimPrefix(field, "."), ".")
+		for _, key := range path {
+			if len(key) == 0 {
+				return nil, fmt.Errorf("invalid watch field %q, expected a path like .spec.replicas", field)
+			}
+		}
+		t.fields = append(t.fields, path)
+	}
+	return t, nil
+}
+
+// remember sets obj as the last version of its objects.
+func (t *changeTracker) remember(obj runtime.Object) {
+	for _, u := range watchedObjects(obj) {
+		t.objects[changeKey(u)] = u
+	}
+}
+
+// forget drops the objects of obj, which were deleted.
+func (t *changeTracker) forget(obj runtime.Object) {
+	for _, u := range watchedObjects(obj) {
+		delete(t.objects, changeKey(u))
+	}
+}
+
+// changes returns the fields changed by obj since the last version of its
+// objects, and remembers obj as their last version.
+func (t *changeTracker) changes(obj runtime.Object) []fieldChange {
+	var changes []fieldChange
+	for _, u := range watchedObjects(obj) {
+		key := changeKey(u)
+		if previous, found := t.objects[key]; found {
+			changes = append(changes, t.diff(previous.Object, u.Object)...)
+		}
+		t.objects[key] = u
+	}
+	return changes
+}
+
+func (t *changeTracker) diff(old, new map[string]interface{}) []fieldChange {
+	if len(t.fields) == 0 {
+		var changes []fieldChange
+		for _, c := range diffValues("", old, new) {
+			if !ignoredChanges[c.path] && !ignoredChanges[strings.SplitN(c.path, "[", 2)[0]] {
+				changes = append(changes, c)
+			}
+		}
+		return changes
+	}
+	var changes []fieldChange
+	for _, path := range t.fields {
+		oldValue, _, _ := unstructured.NestedFieldNoCopy(old, path...)
+		newValue, _, _ := unstructured.NestedFieldNoCopy(new, path...)
+		prefix := ""
+		for _, key := range path {
+			prefix = fieldPath(prefix, key)
+		}
+		changes = append(changes, diffValues(prefix, oldValue, newValue)...)
+	}
+	return changes
+}
+
+// diffValues returns the changes between two values, down to the fields
+// holding scalar values.
+func diffValues(path string, old, new interface{}) []fieldChange {
+	switch oldValue := old.(type) {
+	case map[string]interface{}:
+		newValue, ok := new.(map[string]interface{})
+		if !ok {
+			break
+		}
+		keys := map[string]bool{}
+		for key := range oldValue {
+			keys[key] = true
+		}
+		for key := range newValue {
+			keys[key] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for key := range keys {
+			sorted = append(sorted, key)
+		}
+		sort.Strings(sorted)
+		var changes []fieldChange
+		for _, key := range sorted {
+			changes = append(changes, diffValues(fieldPath(path, key), oldValue[key], newValue[key])...)
+		}
+		return changes
+	case []interface{}:
+		newValue, ok := new.([]interface{})
+		if !ok {
+			break
+		}
+		var changes []fieldChange
+		for i := 0; i < len(oldValue) || i < len(newValue); i++ {
+			var oldItem, newItem interface{}
+			if i < len(oldValue) {
+				oldItem = oldValue[i]
+			}
+			if i < len(newValue) {
+				newItem = newValue[i]
+			}
+			changes = append(changes, diffValues(fmt.Sprintf("%s[%d]", path, i), oldItem, newItem)...)
+		}
+		return changes
+	}
+	if reflect.DeepEqual(old, new) {
+		return nil
+	}
+	return []fieldChange{{path: path, old: old, new: new}}
+}
+
+func fieldPath(path, key string) string {
+	if simpleKey.MatchString(key) {
+		return path + "." + key
+	}
+	return fmt.Sprintf("%s[%q]", path, key)
+}
+
+// watchedObjects returns the objects of a watch event, which are the rows
+// of the table returned by the server when printing on the server side.
+func watchedObjects(obj runtime.Object) []*unstructured.Unstructured {
+	if event, ok := obj.(*metav1.WatchEvent); ok {
+		obj = event.Object.Object
+	}
+	if decoded, err := decodeIntoTable(obj); err == nil {
+		var objects []*unstructured.Unstructured
+		for _, row := range decoded.(*metav1.Table).Rows {
+			if u, ok := row.Object.Object.(*unstructured.Unstructured); ok {
+				objects = append(objects, u)
+			}
+		}
+		return objects
+	}
+	if u, ok := obj.(*unstructured.Unstructured); ok {
+		return []*unstructured.Unstructured{u}
+	}
+	return nil
+}
+
+func changeKey(u *unstructured.Unstructured) string {
+	return u.GetNamespace() + "/" + u.GetName()
+}
+
+// printChanges writes each change on its own line, after the given prefix.
+func printChanges(changes []fieldChange, prefix string, w io.Writer) error {
+	for _, c := range changes {
+		if _, err := fmt.Fprintf(w, "%s%s\n", prefix, c); err != nil {
+			return err
+		}
+	}
+	return nil
+}
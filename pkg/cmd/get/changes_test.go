@@ -0,0 +1,256 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/utils/ptr"
+)
+
+func TestChangeTracker(t *testing.T) {
+	withContainer := func(pod *corev1.Pod) {
+		pod.Spec.Containers = []corev1.Container{{
+			Name:    "nginx",
+			Image:   "nginx:1.25",
+			Command: []string{"sh", "-c"},
+			Args:    []string{"--v=1"},
+			Ports:   []corev1.ContainerPort{{ContainerPort: 80}},
+		}}
+	}
+	pod := testPod(t, "foo", withContainer)
+	changed := testPod(t, "foo", withContainer, func(pod *corev1.Pod) {
+		pod.ResourceVersion = "11"
+		pod.Labels = map[string]string{"app.kubernetes.io/name": "foo"}
+		pod.Spec.Containers[0].Image = "nginx:1.27"
+		pod.Spec.Containers[0].Command = []string{"sh"}
+		pod.Spec.Containers[0].Args = []string{"--v=2", "--debug"}
+		pod.Spec.Containers[0].Ports[0].ContainerPort = 8080
+		pod.Spec.RestartPolicy = corev1.RestartPolicyNever
+		pod.Spec.SecurityContext.RunAsUser = ptr.To[int64](1000)
+		pod.Status.Phase = corev1.PodRunning
+	})
+
+	tests := []struct {
+		name     string
+		fields   []string
+		expected []string
+	}{
+		{
+			name: "all fields",
+			expected: []string{
+				`.metadata.labels["app.kubernetes.io/name"]: <none> → "foo"`,
+				`.spec.containers[0].args[0]: "--v=1" → "--v=2"`,
+				`.spec.containers[0].args[1]: <none> → "--debug"`,
+				`.spec.containers[0].command[1]: "-c" → <none>`,
+				`.spec.containers[0].image: "nginx:1.25" → "nginx:1.27"`,
+				`.spec.containers[0].ports[0].containerPort: 80 → 8080`,
+				`.spec.restartPolicy: "Always" → "Never"`,
+				`.spec.securityContext.runAsUser: <none> → 1000`,
+				`.status.phase: <none> → "Running"`,
+			},
+		},
+		{
+			name:   "watched fields",
+			fields: []string{".status", "spec.restartPolicy", ".metadata.resourceVersion"},
+			expected: []string{
+				`.status.phase: <none> → "Running"`,
+				`.spec.restartPolicy: "Always" → "Never"`,
+				`.metadata.resourceVersion: "10" → "11"`,
+			},
+		},
+		{
+			name:   "unchanged fields",
+			fields: []string{".metadata.name"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker, err := newChangeTracker(tt.fields)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tracker.remember(pod)
+			var changes []string
+			for _, c := range tracker.changes(changed) {
+				changes = append(changes, c.String())
+			}
+			if !reflect.DeepEqual(tt.expected, changes) {
+				t.Errorf("expected changes %q, got %q", tt.expected, changes)
+			}
+		})
+	}
+}
+
+func TestChangeTrackerForget(t *testing.T) {
+	pod := testPod(t, "foo")
+	running := testPod(t, "foo", func(pod *corev1.Pod) {
+		pod.ResourceVersion = "11"
+		pod.Status.Phase = corev1.PodRunning
+	})
+	failed := testPod(t, "foo", func(pod *corev1.Pod) {
+		pod.ResourceVersion = "12"
+		pod.Status.Phase = corev1.PodFailed
+	})
+
+	tracker, err := newChangeTracker(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tracker.remember(pod)
+	tracker.forget(pod)
+	if changes := tracker.changes(running); len(changes) != 0 {
+		t.Errorf("expected no changes for a new object, got %v", changes)
+	}
+	if changes := tracker.changes(failed); len(changes) != 1 || changes[0].path != ".status.phase" {
+		t.Errorf("expected the changes since the last version, got %v", changes)
+	}
+}
+
+func TestNewChangeTrackerErrors(t *testing.T) {
+	for _, field := range []string{"", ".", ".spec..replicas", ".spec."} {
+		if _, err := newChangeTracker([]string{field}); err == nil {
+			t.Errorf("expected an error for the watch field %q", field)
+		}
+	}
+}
+
+func TestWatchResourceShowChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		watchFields string
+		expected    string
+	}{
+		{
+			name: "all fields",
+			expected: `NAME   AGE
+foo    <unknown>
+foo    <unknown>
+    .status.phase: <none> → "Running"
+foo    <unknown>
+`,
+		},
+		{
+			name:        "watched fields",
+			watchFields: ".metadata.resourceVersion",
+			expected: `NAME   AGE
+foo    <unknown>
+foo    <unknown>
+    .metadata.resourceVersion: "10" → "11"
+foo    <unknown>
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pods, events := watchTestData()
+			events[2].Object.(*corev1.Pod).Status.Phase = corev1.PodRunning
+
+			tf := cmdtesting.NewTestFactory().WithNamespace("test")
+			defer tf.Cleanup()
+			codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+			tf.UnstructuredClient = &fake.RESTClient{
+				NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+				Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+					switch req.URL.Path {
+					case "/namespaces/test/pods/foo":
+						return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.ObjBody(codec, &pods[1])}, nil
+					case "/namespaces/test/pods":
+						if req.URL.Query().Get("watch") == "true" && req.URL.Query().Get("fieldSelector") == "metadata.name=foo" {
+							return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: watchBody(codec, events[1:])}, nil
+						}
+						t.Fatalf("request url: %#v,and request: %#v", req.URL, req)
+						return nil, nil
+					default:
+						t.Fatalf("request url: %#v,and request: %#v", req.URL, req)
+						return nil, nil
+					}
+				}),
+			}
+
+			streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+			cmd := NewCmdGet("kubectl", tf, streams)
+			cmd.SetOut(buf)
+			cmd.SetErr(buf)
+
+			cmd.Flags().Set("watch", "true")
+			cmd.Flags().Set("show-changes", "true")
+			if len(tt.watchFields) > 0 {
+				cmd.Flags().Set("watch-fields", tt.watchFields)
+			}
+			cmd.Run(cmd, []string{"pods", "foo"})
+
+			if e, a := tt.expected, buf.String(); e != a {
+				t.Errorf("expected\n%v\ngot\n%v", e, a)
+			}
+		})
+	}
+}
+
+func TestValidateShowChanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(o *GetOptions)
+		wantErr string
+	}{
+		{
+			name:  "watch",
+			setup: func(o *GetOptions) { o.Watch, o.ShowChanges, o.WatchFields = true, true, []string{".status"} },
+		},
+		{
+			name:  "yaml",
+			setup: func(o *GetOptions) { o.Watch, o.ShowChanges, *o.PrintFlags.OutputFormat = true, true, "yaml" },
+		},
+		{
+			name:    "no watch",
+			setup:   func(o *GetOptions) { o.ShowChanges = true },
+			wantErr: "--show-changes option can only be used with --watch or --watch-only",
+		},
+		{
+			name:    "watch fields without changes",
+			setup:   func(o *GetOptions) { o.Watch, o.WatchFields = true, []string{".status"} },
+			wantErr: "--watch-fields option can only be used with --show-changes",
+		},
+		{
+			name:    "json",
+			setup:   func(o *GetOptions) { o.WatchOnly, o.ShowChanges, *o.PrintFlags.OutputFormat = true, true, "json" },
+			wantErr: "--show-changes option cannot be used with json printer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewGetOptions("kubectl", genericiooptions.NewTestIOStreamsDiscard())
+			tt.setup(o)
+			err := o.Validate()
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(tt.wantErr) > 0 && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,259 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+)
+
+// contextAnnotation is the annotation set on the objects printed in the
+// formats without a CONTEXT column, to tell the context they were listed in.
+const contextAnnotation = "kubectl.kubernetes.io/context"
+
+// contextColumn is the first column of the custom columns printed for
+// several contexts.
+var contextColumn = Column{Header: "CONTEXT", expression: contextExpression{}}
+
+// contextExpression is the context of an object: the CONTEXT cell of the
+// tables printed by the server, or else the context annotation.
+type contextExpression struct{}
+
+func (contextExpression) evaluate(input columnInput) string {
+	if cell, found := input.cells["context"]; found {
+		return fmt.Sprint(cell)
+	}
+	if input.object != nil {
+		if accessor, err := meta.Accessor(input.object); err == nil {
+			if context, found := accessor.GetAnnotations()[contextAnnotation]; found {
+				return context
+			}
+		}
+	}
+	return "<none>"
+}
+
+// contextResult holds the objects listed in a context of the kubeconfig.
+type contextResult struct {
+	context string
+	infos   []*resource.Info
+	err     error
+}
+
+// resolveContexts returns the contexts named by the given names or glob
+// patterns, among the contexts of the kubeconfig.
+func resolveContexts(available []string, patterns []string) ([]string, error) {
+	sorted := append([]string(nil), available...)
+	sort.Strings(sorted)
+	exists := map[string]bool{}
+	for _, context := range available {
+		exists[context] = true
+	}
+
+	var contexts []string
+	seen := map[string]bool{}
+	add := func(context string) {
+		if !seen[context] {
+			seen[context] = true
+			contexts = append(contexts, context)
+		}
+	}
+	for _, pattern := range patterns {
+		if !strings.ContainsAny(pattern, "*?[") {
+			if !exists[pattern] {
+				return nil, fmt.Errorf("context %q does not exist", pattern)
+			}
+			add(pattern)
+			continue
+		}
+		matched := false
+		for _, context := range sorted {
+			ok, err := path.Match(pattern, context)
+			if err != nil {
+				return nil, fmt.Errorf("invalid context pattern %q: %v", pattern, err)
+			}
+			if ok {
+				matched = true
+				add(context)
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("no context matches %q", pattern)
+		}
+	}
+	return contexts, nil
+}
+
+// completeContexts resolves the contexts to get the objects from.
+func (o *GetOptions) completeContexts(f cmdutil.Factory) error {
+	patterns := o.Contexts
+	if len(o.AllContexts) > 0 {
+		patterns = append(patterns, o.AllContexts)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+	rawConfig, err := f.ToRawKubeConfigLoader().RawConfig()
+	if err != nil {
+		return err
+	}
+	available := make([]string, 0, len(rawConfig.Contexts))
+	for context := range rawConfig.Contexts {
+		available = append(available, context)
+	}
+	o.contexts, err = resolveContexts(available, patterns)
+	return err
+}
+
+// getContexts lists the objects in each context concurrently.
+func (o *GetOptions) getContexts(f cmdutil.Factory, args []string) []contextResult {
+	results := make([]contextResult, len(o.contexts))
+	var wg sync.WaitGroup
+	for i, context := range o.contexts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			infos, err := o.getContext(f, context, args)
+			results[i] = contextResult{context: context, infos: infos, err: err}
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+func (o *GetOptions) getContext(f cmdutil.Factory, context string, args []string) ([]*resource.Info, error) {
+	cf, err := cmdutil.NewFactoryForContext(f, context)
+	if err != nil {
+		return nil, err
+	}
+	// the namespace of each context is used, unless one is given explicitly
+	namespace := o.Namespace
+	if !o.ExplicitNamespace {
+		namespace, _, err = cf.ToRawKubeConfigLoader().Namespace()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	chunkSize := o.ChunkSize
+	if len(o.SortBy) > 0 {
+		chunkSize = 0
+	}
+	r := cf.NewBuilder().
+		Unstructured().
+		NamespaceParam(namespace).DefaultNamespace().AllNamespaces(o.AllNamespaces).
+		FilenameParam(o.ExplicitNamespace, &o.FilenameOptions).
+		LabelSelectorParam(o.LabelSelector).
+		FieldSelectorParam(o.FieldSelector).
+		Subresource(o.Subresource).
+		RequestChunksOf(chunkSize).
+		ResourceTypeOrNameArgs(true, args...).
+		ContinueOnError().
+		Latest().
+		Flatten().
+		TransformRequests(o.transformRequests).
+		Do()
+	if o.IgnoreNotFound {
+		r.IgnoreErrors(apierrors.IsNotFound)
+	}
+	if err := r.Err(); err != nil {
+		return nil, err
+	}
+	return r.Infos()
+}
+
+// runContexts gets the objects from several contexts, and prints them.
+func (o *GetOptions) runContexts(f cmdutil.Factory, args []string) error {
+	return o.printContexts(o.getContexts(f, args))
+}
+
+// printContexts prints the objects listed in several contexts. The tables
+// of the same resource are merged, with a CONTEXT column, while in the other
+// formats the objects of all the contexts are printed as one list, annotated
+// with their context, which custom columns print in a CONTEXT column. The
+// contexts which failed are reported after the objects of the others.
+func (o *GetOptions) printContexts(results []contextResult) error {
+	var errs []error
+	for _, result := range results {
+		if result.err != nil {
+			errs = append(errs, fmt.Errorf("context %q: %w", result.context, result.err))
+		}
+	}
+
+	if !o.IsHumanReadablePrinter {
+		var infos []*resource.Info
+		for _, result := range results {
+			for _, info := range result.infos {
+				withContext := *info
+				withContext.Object = withContextAnnotation(info.Object, result.context)
+				infos = append(infos, &withContext)
+			}
+		}
+		return o.printObjects(infos, false, errs)
+	}
+
+	// the objects are grouped by resource, so that the tables of a resource
+	// are printed as one
+	var resources []schema.GroupVersionResource
+	byResource := map[schema.GroupVersionResource][]*resource.Info{}
+	for _, result := range results {
+		for _, info := range result.infos {
+			var gvr schema.GroupVersionResource
+			if info.Mapping != nil {
+				gvr = info.Mapping.Resource
+			}
+			if _, found := byResource[gvr]; !found {
+				resources = append(resources, gvr)
+			}
+			withContext := *info
+			withContext.Object = withContextColumn(info.Object, result.context)
+			byResource[gvr] = append(byResource[gvr], &withContext)
+		}
+	}
+	var infos []*resource.Info
+	for _, gvr := range resources {
+		infos = append(infos, byResource[gvr]...)
+	}
+	return o.printHumanReadable(infos, errs)
+}
+
+// withContextAnnotation returns a copy of obj annotated with the context it
+// was listed in.
+func withContextAnnotation(obj runtime.Object, context string) runtime.Object {
+	obj = obj.DeepCopyObject()
+	accessor, err := meta.Accessor(obj)
+	if err != nil {
+		return obj
+	}
+	annotations := accessor.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[contextAnnotation] = context
+	accessor.SetAnnotations(annotations)
+	return obj
+}
@@ -0,0 +1,298 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+	"sigs.k8s.io/yaml"
+)
+
+func TestResolveContexts(t *testing.T) {
+	available := []string{"staging", "prod-us", "prod-eu", "dev"}
+	tests := []struct {
+		name     string
+		patterns []string
+		expected []string
+		wantErr  string
+	}{
+		{
+			name:     "names",
+			patterns: []string{"staging", "dev"},
+			expected: []string{"staging", "dev"},
+		},
+		{
+			name:     "glob",
+			patterns: []string{"prod-*"},
+			expected: []string{"prod-eu", "prod-us"},
+		},
+		{
+			name:     "all",
+			patterns: []string{"staging", "*"},
+			expected: []string{"staging", "dev", "prod-eu", "prod-us"},
+		},
+		{
+			name:     "unknown name",
+			patterns: []string{"test"},
+			wantErr:  `context "test" does not exist`,
+		},
+		{
+			name:     "unmatched glob",
+			patterns: []string{"test-*"},
+			wantErr:  `no context matches "test-*"`,
+		},
+		{
+			name:     "invalid glob",
+			patterns: []string{"prod-["},
+			wantErr:  `invalid context pattern "prod-["`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contexts, err := resolveContexts(available, tt.patterns)
+			if len(tt.wantErr) > 0 {
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(tt.expected, contexts) {
+				t.Errorf("expected contexts %v, got %v", tt.expected, contexts)
+			}
+		})
+	}
+}
+
+var podsMapping = &meta.RESTMapping{
+	Resource:         schema.GroupVersionResource{Version: "v1", Resource: "pods"},
+	GroupVersionKind: schema.GroupVersionKind{Version: "v1", Kind: "Pod"},
+	Scope:            meta.RESTScopeNamespace,
+}
+
+func newContextOptions(t *testing.T, output string) (*GetOptions, *strings.Builder) {
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	t.Cleanup(tf.Cleanup)
+
+	out := &strings.Builder{}
+	o := NewGetOptions("kubectl", genericiooptions.IOStreams{In: strings.NewReader(""), Out: out, ErrOut: io.Discard})
+	cmd := &cobra.Command{}
+	o.PrintFlags.AddFlags(cmd)
+	if len(output) > 0 {
+		cmd.Flags().Set("output", output)
+	}
+	if err := o.Complete(tf, cmd, []string{"pods"}); err != nil {
+		t.Fatal(err)
+	}
+	return o, out
+}
+
+func TestPrintContextsTables(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+	table := func(pods ...corev1.Pod) []*resource.Info {
+		data, err := io.ReadAll(podTableObjBody(codec, pods...))
+		if err != nil {
+			t.Fatal(err)
+		}
+		obj, err := runtime.Decode(unstructured.UnstructuredJSONScheme, data)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return []*resource.Info{{Mapping: podsMapping, Namespace: "test", Object: obj}}
+	}
+
+	o, out := newContextOptions(t, "")
+	err := o.printContexts([]contextResult{
+		{context: "a", infos: table(pods.Items...)},
+		{context: "b", err: errors.New("connection refused")},
+		{context: "c", infos: table(pods.Items[0])},
+	})
+	if err == nil || err.Error() != `context "b": connection refused` {
+		t.Errorf("expected the error of the context b, got %v", err)
+	}
+
+	expected := `CONTEXT   NAME   READY   STATUS   RESTARTS   AGE
+a         foo    0/0              0          <unknown>
+a         bar    0/0              0          <unknown>
+c         foo    0/0              0          <unknown>
+`
+	if e, a := expected, out.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+// newContextInfos returns the infos of the test pods with the given names,
+// as listed in a context.
+func newContextInfos(t *testing.T, names ...string) []*resource.Info {
+	var infos []*resource.Info
+	for _, name := range names {
+		infos = append(infos, &resource.Info{Mapping: podsMapping, Namespace: "test", Name: name, Object: testPod(t, name)})
+	}
+	return infos
+}
+
+func TestPrintContextsClientColumns(t *testing.T) {
+	tests := []struct {
+		name     string
+		output   string
+		expected string
+	}{
+		{
+			name:   "server-print=false",
+			output: "",
+			expected: `CONTEXT   NAME   AGE
+a         bar    <unknown>
+c         foo    <unknown>
+`,
+		},
+		{
+			name:   "custom columns",
+			output: "custom-columns=NAME:.metadata.name,PHASE:.status.phase",
+			expected: `CONTEXT   NAME   PHASE
+a         bar    <none>
+c         foo    <none>
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o, out := newContextOptions(t, tt.output)
+			// the objects are not tables
+			o.ServerPrint = false
+			o.contexts = []string{"a", "c"}
+			if err := o.printContexts([]contextResult{
+				{context: "a", infos: newContextInfos(t, "bar")},
+				{context: "c", infos: newContextInfos(t, "foo")},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			if e, a := tt.expected, out.String(); e != a {
+				t.Errorf("expected\n%v\ngot\n%v", e, a)
+			}
+		})
+	}
+}
+
+func TestPrintContextsName(t *testing.T) {
+	o, out := newContextOptions(t, "name")
+	if err := o.printContexts([]contextResult{
+		{context: "a", infos: newContextInfos(t, "bar")},
+		{context: "b", infos: newContextInfos(t, "foo", "bar")},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	expected := "pod/bar\npod/foo\npod/bar\n"
+	if e, a := expected, out.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestPrintContextsList(t *testing.T) {
+	for _, output := range []string{"json", "yaml"} {
+		t.Run(output, func(t *testing.T) {
+			o, out := newContextOptions(t, output)
+			infos := newContextInfos(t, "foo", "bar")
+			err := o.printContexts([]contextResult{
+				{context: "a", infos: newContextInfos(t, "bar")},
+				{context: "b", err: errors.New("connection refused")},
+				{context: "c", infos: infos},
+			})
+			if err == nil || err.Error() != `context "b": connection refused` {
+				t.Errorf("expected the error of the context b, got %v", err)
+			}
+
+			list := &unstructured.UnstructuredList{}
+			data, err := yaml.YAMLToJSON([]byte(out.String()))
+			if err != nil {
+				t.Fatalf("expected a single document, got %v:\n%s", err, out.String())
+			}
+			if err := list.UnmarshalJSON(data); err != nil {
+				t.Fatalf("expected a list, got %v:\n%s", err, out.String())
+			}
+			var objects []string
+			for _, item := range list.Items {
+				objects = append(objects, item.GetAnnotations()[contextAnnotation]+"/"+item.GetName())
+			}
+			expected := []string{"a/bar", "c/foo", "c/bar"}
+			if !reflect.DeepEqual(expected, objects) {
+				t.Errorf("expected the objects %v, got %v", expected, objects)
+			}
+			if annotations := infos[0].Object.(*unstructured.Unstructured).GetAnnotations(); len(annotations) > 0 {
+				t.Errorf("expected the listed objects to be left unchanged, got %v", annotations)
+			}
+		})
+	}
+}
+
+func TestValidateContexts(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(o *GetOptions)
+		wantErr string
+	}{
+		{
+			name:  "contexts",
+			setup: func(o *GetOptions) { o.Contexts = []string{"a", "b"} },
+		},
+		{
+			name:    "watch",
+			setup:   func(o *GetOptions) { o.AllContexts, o.Watch = "*", true },
+			wantErr: "--contexts and --all-contexts options cannot be used with --watch or --watch-only",
+		},
+		{
+			name:    "raw",
+			setup:   func(o *GetOptions) { o.Contexts, o.Raw = []string{"a"}, "/api" },
+			wantErr: "--contexts and --all-contexts options cannot be used with --raw",
+		},
+		{
+			name:    "group by",
+			setup:   func(o *GetOptions) { o.Contexts, o.GroupBy = []string{"a"}, []string{"status"} },
+			wantErr: "--contexts and --all-contexts options cannot be used with --group-by or --count",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewGetOptions("kubectl", genericiooptions.NewTestIOStreamsDiscard())
+			tt.setup(o)
+			err := o.Validate()
+			if len(tt.wantErr) == 0 && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if len(tt.wantErr) > 0 && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("expected error %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"unicode"
 
 	"github.com/liggitt/tabwriter"
 
@@ -67,27 +68,47 @@ func RelaxedJSONPathExpression(pathExpression string) (string, error) {
 	return fmt.Sprintf("{.%s}", fieldSpec), nil
 }
 
+// newColumn returns the column of a header and a field spec, which is either
+// a JSONPath expression or a computed expression, see parseColumnExpression.
+func newColumn(header, fieldSpec string) (Column, error) {
+	if isColumnFunction(fieldSpec) {
+		expression, err := parseColumnExpression(fieldSpec)
+		if err != nil {
+			return Column{}, err
+		}
+		return Column{Header: header, FieldSpec: strings.TrimSpace(fieldSpec), expression: expression}, nil
+	}
+	spec, err := RelaxedJSONPathExpression(fieldSpec)
+	if err != nil {
+		return Column{}, err
+	}
+	return Column{Header: header, FieldSpec: spec}, nil
+}
+
 // NewCustomColumnsPrinterFromSpec creates a custom columns printer from a comma separated list of <header>:<jsonpath-field-spec> pairs.
 // e.g. NAME:metadata.name,API_VERSION:apiVersion creates a printer that prints:
 //
 //	NAME               API_VERSION
 //	foo                bar
+//
+// The field specs can also be computed expressions, like AGE:age(.metadata.creationTimestamp)
+// or STATUS:column(Status), whose commas are not separating columns.
 func NewCustomColumnsPrinterFromSpec(spec string, decoder runtime.Decoder, noHeaders bool) (*CustomColumnsPrinter, error) {
 	if len(spec) == 0 {
 		return nil, fmt.Errorf("custom-columns format specified but no custom columns given")
 	}
-	parts := strings.Split(spec, ",")
+	parts := splitOutsideBrackets(spec, func(r rune) bool { return r == ',' })
 	columns := make([]Column, len(parts))
 	for ix := range parts {
 		colSpec := strings.SplitN(parts[ix], ":", 2)
 		if len(colSpec) != 2 {
 			return nil, fmt.Errorf("unexpected custom-columns spec: %s, expected <header>:<json-path-expr>", parts[ix])
 		}
-		spec, err := RelaxedJSONPathExpression(colSpec[1])
+		column, err := newColumn(colSpec[0], colSpec[1])
 		if err != nil {
 			return nil, err
 		}
-		columns[ix] = Column{Header: colSpec[0], FieldSpec: spec}
+		columns[ix] = column
 	}
 	return &CustomColumnsPrinter{Columns: columns, Decoder: decoder, NoHeaders: noHeaders}, nil
 }
@@ -107,6 +128,8 @@ func splitOnWhitespace(line string) []string {
 // For example, the template below:
 // NAME               API_VERSION
 // {metadata.name}    {apiVersion}
+// The field specs can also be computed expressions, which may contain whitespace in their parentheses, like
+// if(.spec.unschedulable, 'Cordoned', column(Status)).
 func NewCustomColumnsPrinterFromTemplate(templateReader io.Reader, decoder runtime.Decoder) (*CustomColumnsPrinter, error) {
 	scanner := bufio.NewScanner(templateReader)
 	if !scanner.Scan() {
@@ -117,7 +140,12 @@ func NewCustomColumnsPrinterFromTemplate(templateReader io.Reader, decoder runti
 	if !scanner.Scan() {
 		return nil, fmt.Errorf("invalid template, missing spec line. Expected format is one line of space separated headers, one line of space separated column specs.")
 	}
-	specs := splitOnWhitespace(scanner.Text())
+	var specs []string
+	for _, spec := range splitOutsideBrackets(scanner.Text(), unicode.IsSpace) {
+		if len(spec) > 0 {
+			specs = append(specs, spec)
+		}
+	}
 
 	if len(headers) != len(specs) {
 		return nil, fmt.Errorf("number of headers (%d) and field specifications (%d) don't match", len(headers), len(specs))
@@ -125,14 +153,11 @@ func NewCustomColumnsPrinterFromTemplate(templateReader io.Reader, decoder runti
 
 	columns := make([]Column, len(headers))
 	for ix := range headers {
-		spec, err := RelaxedJSONPathExpression(specs[ix])
+		column, err := newColumn(headers[ix], specs[ix])
 		if err != nil {
 			return nil, err
 		}
-		columns[ix] = Column{
-			Header:    headers[ix],
-			FieldSpec: spec,
-		}
+		columns[ix] = column
 	}
 	return &CustomColumnsPrinter{Columns: columns, Decoder: decoder, NoHeaders: false}, nil
 }
@@ -143,7 +168,12 @@ type Column struct {
 	Header string
 	// The pointer to the field in the object to print in JSONPath form
 	// e.g. {.ObjectMeta.Name}, see pkg/util/jsonpath for more details.
+	// Or a computed expression, e.g. sum(.spec.containers[*].resources.requests.cpu).
 	FieldSpec string
+
+	// expression computes the value of the column, when the field spec is
+	// not a JSONPath expression.
+	expression columnExpression
 }
 
 // CustomColumnPrinter is a printer that knows how to print arbitrary columns
@@ -182,31 +212,88 @@ func (s *CustomColumnsPrinter) PrintObj(obj runtime.Object, out io.Writer) error
 	}
 	parsers := make([]*jsonpath.JSONPath, len(s.Columns))
 	for ix := range s.Columns {
+		if s.Columns[ix].expression != nil {
+			continue
+		}
 		parsers[ix] = jsonpath.New(fmt.Sprintf("column%d", ix)).AllowMissingKeys(true)
 		if err := parsers[ix].Parse(s.Columns[ix].FieldSpec); err != nil {
 			return err
 		}
 	}
 
+	// the tables printed by the server have a row for each object, whose
+	// cells can be printed by the columns
+	if table, isTable := obj.(*metav1.Table); isTable {
+		for _, row := range table.Rows {
+			if err := s.printOneObject(tableRowObject(row), tableRowCells(table, row), parsers, out); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if event, isEvent := obj.(*metav1.WatchEvent); isEvent {
+		if table, isTable := event.Object.Object.(*metav1.Table); isTable {
+			for _, row := range table.Rows {
+				rowEvent := &metav1.WatchEvent{Type: event.Type, Object: runtime.RawExtension{Object: tableRowObject(row)}}
+				if err := s.printOneObject(rowEvent, tableRowCells(table, row), parsers, out); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
 	if meta.IsListType(obj) {
 		objs, err := meta.ExtractList(obj)
 		if err != nil {
 			return err
 		}
 		for ix := range objs {
-			if err := s.printOneObject(objs[ix], parsers, out); err != nil {
+			if err := s.printOneObject(objs[ix], nil, parsers, out); err != nil {
 				return err
 			}
 		}
 	} else {
-		if err := s.printOneObject(obj, parsers, out); err != nil {
+		if err := s.printOneObject(obj, nil, parsers, out); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (s *CustomColumnsPrinter) printOneObject(obj runtime.Object, parsers []*jsonpath.JSONPath, out io.Writer) error {
+// usesServerColumns returns whether a column refers to the columns of the
+// tables printed by the server.
+func (s *CustomColumnsPrinter) usesServerColumns() bool {
+	for _, column := range s.Columns {
+		if column.expression != nil && usesServerColumns(column.expression) {
+			return true
+		}
+	}
+	return false
+}
+
+// tableRowObject returns the object of a row of a table, which is empty when
+// the server did not include it.
+func tableRowObject(row metav1.TableRow) runtime.Object {
+	if row.Object.Object == nil {
+		return &unstructured.Unstructured{Object: map[string]interface{}{}}
+	}
+	return row.Object.Object
+}
+
+// tableRowCells returns the cells of a row of a table, by lowercase column
+// name.
+func tableRowCells(table *metav1.Table, row metav1.TableRow) map[string]interface{} {
+	cells := map[string]interface{}{}
+	for i, column := range table.ColumnDefinitions {
+		if i < len(row.Cells) {
+			cells[strings.ToLower(column.Name)] = row.Cells[i]
+		}
+	}
+	return cells
+}
+
+func (s *CustomColumnsPrinter) printOneObject(obj runtime.Object, cells map[string]interface{}, parsers []*jsonpath.JSONPath, out io.Writer) error {
 	columns := make([]string, len(parsers))
 	switch u := obj.(type) {
 	case *metav1.WatchEvent:
@@ -234,6 +321,10 @@ func (s *CustomColumnsPrinter) printOneObject(obj runtime.Object, parsers []*jso
 	}
 
 	for ix := range parsers {
+		if expression := s.Columns[ix].expression; expression != nil {
+			columns[ix] = expression.evaluate(columnInput{object: obj, cells: cells})
+			continue
+		}
 		parser := parsers[ix]
 
 		var values [][]reflect.Value
@@ -0,0 +1,340 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+var columnFunctionRegexp = regexp.MustCompile(`^([a-z]+)\((.*)\)$`)
+
+// isColumnFunction returns whether the spec of a custom column is a computed
+// expression, like sum(.spec.containers[*].resources.requests.cpu), rather
+// than a JSONPath expression.
+func isColumnFunction(spec string) bool {
+	return columnFunctionRegexp.MatchString(strings.TrimSpace(spec))
+}
+
+// columnInput is what the value of a custom column is computed from: an
+// object, and the cells of its row when the server printed it in a table,
+// by lowercase column name.
+type columnInput struct {
+	object runtime.Object
+	cells  map[string]interface{}
+}
+
+// columnExpression computes the value of a custom column.
+type columnExpression interface {
+	evaluate(input columnInput) string
+}
+
+// parseColumnExpression parses a computed expression, which is one of:
+//   - a JSONPath expression, like .metadata.name
+//   - a quoted string, like 'Ready'
+//   - age(PATH), the time elapsed since the timestamp at PATH
+//   - duration(PATH), the duration at PATH, in seconds or like 1h30m
+//   - sum(PATH), the sum of the numbers or quantities at PATH
+//   - count(PATH), the number of values at PATH, counting the elements of lists
+//   - column(NAME), the cell of the column NAME of the table printed by the server
+//   - if(PREDICATE, THEN, ELSE), THEN when the object matches the predicate,
+//     written like the ones of --where, and ELSE otherwise
+func parseColumnExpression(spec string) (columnExpression, error) {
+	spec = strings.TrimSpace(spec)
+	if len(spec) >= 2 && (spec[0] == '"' || spec[0] == '\'') && spec[len(spec)-1] == spec[0] {
+		return literalExpression(spec[1 : len(spec)-1]), nil
+	}
+	submatches := columnFunctionRegexp.FindStringSubmatch(spec)
+	if submatches == nil {
+		parser, err := parseColumnJSONPath(spec)
+		if err != nil {
+			return nil, err
+		}
+		return &jsonPathExpression{parser: parser}, nil
+	}
+
+	function := submatches[1]
+	args := splitOutsideBrackets(submatches[2], func(r rune) bool { return r == ',' })
+	for i := range args {
+		args[i] = strings.TrimSpace(args[i])
+	}
+	expectArgs := func(n int) error {
+		if len(args) != n {
+			return fmt.Errorf("invalid custom column expression %q, %s() expects %d argument(s)", spec, function, n)
+		}
+		for _, arg := range args {
+			if len(arg) == 0 {
+				return fmt.Errorf("invalid custom column expression %q, empty argument of %s()", spec, function)
+			}
+		}
+		return nil
+	}
+
+	switch function {
+	case "age", "duration", "sum", "count":
+		if err := expectArgs(1); err != nil {
+			return nil, err
+		}
+		parser, err := parseColumnJSONPath(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return &functionExpression{function: function, parser: parser}, nil
+	case "column":
+		if err := expectArgs(1); err != nil {
+			return nil, err
+		}
+		return serverColumnExpression(strings.ToLower(strings.Trim(args[0], `"'`))), nil
+	case "if":
+		if err := expectArgs(3); err != nil {
+			return nil, err
+		}
+		predicates, err := parseWherePredicates(args[:1])
+		if err != nil {
+			return nil, err
+		}
+		then, err := parseColumnExpression(args[1])
+		if err != nil {
+			return nil, err
+		}
+		otherwise, err := parseColumnExpression(args[2])
+		if err != nil {
+			return nil, err
+		}
+		return &ifExpression{predicate: predicates[0], then: then, otherwise: otherwise}, nil
+	}
+	return nil, fmt.Errorf("invalid custom column expression %q, unknown function %s(), expected one of age, duration, sum, count, column or if", spec, function)
+}
+
+func parseColumnJSONPath(spec string) (*jsonpath.JSONPath, error) {
+	field, err := RelaxedJSONPathExpression(spec)
+	if err != nil {
+		return nil, err
+	}
+	parser := jsonpath.New("column").AllowMissingKeys(true)
+	if err := parser.Parse(field); err != nil {
+		return nil, err
+	}
+	return parser, nil
+}
+
+// splitOutsideBrackets splits s around the runes which are separators,
+// except in brackets, braces, parentheses and quoted strings.
+func splitOutsideBrackets(s string, isSeparator func(rune) bool) []string {
+	var fields []string
+	depth, start := 0, 0
+	var quote rune
+	for i, r := range s {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case strings.ContainsRune("[({", r):
+			depth++
+		case strings.ContainsRune("])}", r):
+			depth--
+		case depth == 0 && isSeparator(r):
+			fields = append(fields, s[start:i])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	return append(fields, s[start:])
+}
+
+// columnValues returns the values found by a JSONPath expression in the
+// object of a custom column.
+func columnValues(parser *jsonpath.JSONPath, obj runtime.Object) []reflect.Value {
+	if obj == nil {
+		return nil
+	}
+	if u, ok := obj.(runtime.Unstructured); ok {
+		obj = &unstructured.Unstructured{Object: u.UnstructuredContent()}
+	}
+	results, err := findJSONPathResults(parser, obj)
+	if err != nil {
+		return nil
+	}
+	var values []reflect.Value
+	for _, result := range results {
+		for _, value := range result {
+			if value, ok := indirectValue(value); ok {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// literalExpression is a quoted string.
+type literalExpression string
+
+func (e literalExpression) evaluate(input columnInput) string {
+	return string(e)
+}
+
+// jsonPathExpression is the values found by a JSONPath expression, joined
+// like the ones of the other custom columns.
+type jsonPathExpression struct {
+	parser *jsonpath.JSONPath
+}
+
+func (e *jsonPathExpression) evaluate(input columnInput) string {
+	values := columnValues(e.parser, input.object)
+	if len(values) == 0 {
+		return "<none>"
+	}
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = printers.EscapeTerminal(fmt.Sprint(value.Interface()))
+	}
+	return strings.Join(strs, ",")
+}
+
+// functionExpression formats or aggregates the values found by a JSONPath
+// expression.
+type functionExpression struct {
+	function string
+	parser   *jsonpath.JSONPath
+}
+
+func (e *functionExpression) evaluate(input columnInput) string {
+	values := columnValues(e.parser, input.object)
+	switch e.function {
+	case "count":
+		count := 0
+		for _, value := range values {
+			switch value.Kind() {
+			case reflect.Slice, reflect.Map, reflect.Array:
+				count += value.Len()
+			default:
+				count++
+			}
+		}
+		return strconv.Itoa(count)
+	case "sum":
+		if len(values) == 0 {
+			return "<none>"
+		}
+		var sum *apiresource.Quantity
+		for _, value := range values {
+			q, err := apiresource.ParseQuantity(fmt.Sprint(value.Interface()))
+			if err != nil {
+				return "<invalid>"
+			}
+			if sum == nil {
+				sum = &q
+			} else {
+				sum.Add(q)
+			}
+		}
+		return sum.String()
+	}
+
+	if len(values) == 0 {
+		return "<none>"
+	}
+	value := values[0].Interface()
+	if e.function == "age" {
+		var timestamp time.Time
+		switch t := value.(type) {
+		case metav1.Time:
+			timestamp = t.Time
+		case time.Time:
+			timestamp = t
+		default:
+			parsed, err := time.Parse(time.RFC3339, fmt.Sprint(value))
+			if err != nil {
+				return "<invalid>"
+			}
+			timestamp = parsed
+		}
+		if timestamp.IsZero() {
+			return "<unknown>"
+		}
+		return duration.HumanDuration(time.Since(timestamp))
+	}
+
+	// a duration is given in seconds, or like 1h30m
+	switch d := value.(type) {
+	case metav1.Duration:
+		return duration.HumanDuration(d.Duration)
+	case time.Duration:
+		return duration.HumanDuration(d)
+	}
+	if seconds, err := strconv.ParseFloat(fmt.Sprint(value), 64); err == nil {
+		return duration.HumanDuration(time.Duration(seconds * float64(time.Second)))
+	}
+	if parsed, err := time.ParseDuration(fmt.Sprint(value)); err == nil {
+		return duration.HumanDuration(parsed)
+	}
+	return "<invalid>"
+}
+
+// serverColumnExpression is the cell of a column of the table printed by
+// the server, by lowercase name.
+type serverColumnExpression string
+
+func (e serverColumnExpression) evaluate(input columnInput) string {
+	cell, found := input.cells[string(e)]
+	if !found || cell == nil {
+		return "<none>"
+	}
+	return printers.EscapeTerminal(fmt.Sprint(cell))
+}
+
+// ifExpression is a value depending on whether the object matches a
+// predicate.
+type ifExpression struct {
+	predicate wherePredicate
+	then      columnExpression
+	otherwise columnExpression
+}
+
+func (e *ifExpression) evaluate(input columnInput) string {
+	if input.object != nil && e.predicate.matches(input.object) {
+		return e.then.evaluate(input)
+	}
+	return e.otherwise.evaluate(input)
+}
+
+// usesServerColumns returns whether an expression refers to the columns of
+// the tables printed by the server.
+func usesServerColumns(e columnExpression) bool {
+	switch t := e.(type) {
+	case serverColumnExpression:
+		return true
+	case *ifExpression:
+		return usesServerColumns(t.then) || usesServerColumns(t.otherwise)
+	}
+	return false
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+	"k8s.io/utils/ptr"
+)
+
+// runningColumnPod is created five hours ago and runs with containers
+// requesting 100m and 250m cpu.
+func runningColumnPod(pod *corev1.Pod) {
+	pod.CreationTimestamp = metav1.NewTime(time.Now().Add(-5 * time.Hour))
+	pod.Annotations = map[string]string{"timeout": "1h30m"}
+	pod.Spec.NodeName = "node-1"
+	pod.Spec.ActiveDeadlineSeconds = ptr.To[int64](90)
+	pod.Spec.Containers = []corev1.Container{
+		{Name: "a", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: apiresource.MustParse("100m")}}},
+		{Name: "b", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: apiresource.MustParse("250m")}}},
+	}
+	pod.Status.Phase = corev1.PodRunning
+}
+
+func TestColumnExpressions(t *testing.T) {
+	input := columnInput{
+		object: testPod(t, "web", runningColumnPod),
+		cells:  map[string]interface{}{"status": "Running", "restarts": int64(3)},
+	}
+	tests := []struct {
+		spec     string
+		expected string
+	}{
+		{spec: "age(.metadata.creationTimestamp)", expected: "5h"},
+		{spec: "age(.metadata.deletionTimestamp)", expected: "<none>"},
+		{spec: "duration(.spec.activeDeadlineSeconds)", expected: "90s"},
+		{spec: "duration(.metadata.annotations.timeout)", expected: "90m"},
+		{spec: "duration(.spec.nodeName)", expected: "<invalid>"},
+		{spec: "sum(.spec.containers[*].resources.requests.cpu)", expected: "350m"},
+		{spec: "sum(.spec.containers[*].resources.limits.cpu)", expected: "<none>"},
+		{spec: "sum(.spec.nodeName)", expected: "<invalid>"},
+		{spec: "count(.spec.containers)", expected: "2"},
+		{spec: "count(.spec.containers[*].resources.requests.cpu)", expected: "2"},
+		{spec: "count(.spec.volumes)", expected: "0"},
+		{spec: "column(Status)", expected: "Running"},
+		{spec: "column(IP)", expected: "<none>"},
+		{spec: "if(.status.phase==Running, 'up', column(Restarts))", expected: "up"},
+		{spec: "if(.status.phase!=Running, 'up', column(Restarts))", expected: "3"},
+		{spec: `if(.spec.unschedulable, "cordoned", .spec.nodeName)`, expected: "node-1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			expression, err := parseColumnExpression(tt.spec)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if value := expression.evaluate(input); value != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, value)
+			}
+		})
+	}
+}
+
+func TestParseColumnExpressionErrors(t *testing.T) {
+	for _, spec := range []string{"sum()", "sum(.a, .b)", "avg(.a)", "if(.a, 'x')", "if(==x, 'a', 'b')", "age({.a)"} {
+		if _, err := parseColumnExpression(spec); err == nil {
+			t.Errorf("expected an error for the expression %q", spec)
+		}
+	}
+}
+
+func TestColumnPrinterExpressions(t *testing.T) {
+	specPrinter, err := NewCustomColumnsPrinterFromSpec("NAME:.metadata.name,CPU:sum(.spec.containers[*].resources.requests.cpu),STATUS:if(.status.phase==Running, 'up', column(Status))", decoder, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	templatePrinter, err := NewCustomColumnsPrinterFromTemplate(bytes.NewBufferString(`NAME              CPU                                                STATUS
+{.metadata.name}  sum(.spec.containers[*].resources.requests.cpu)    if(.status.phase==Running, 'up', column(Status))`), decoder)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	stopped := testPod(t, "db", runningColumnPod, func(pod *corev1.Pod) { pod.Status.Phase = corev1.PodSucceeded })
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string", Format: "name"}, {Name: "Status", Type: "string"}},
+		Rows: []metav1.TableRow{
+			{Cells: []interface{}{"web", "Running"}, Object: runtime.RawExtension{Object: testPod(t, "web", runningColumnPod)}},
+			{Cells: []interface{}{"db", "Completed"}, Object: runtime.RawExtension{Object: stopped}},
+		},
+	}
+	expected := `NAME   CPU    STATUS
+web    350m   up
+db     350m   Completed
+`
+	for name, printer := range map[string]*CustomColumnsPrinter{"spec": specPrinter, "template": templatePrinter} {
+		t.Run(name, func(t *testing.T) {
+			if !printer.usesServerColumns() {
+				t.Errorf("expected the printer to use the columns of the server")
+			}
+			out := &bytes.Buffer{}
+			if err := printer.PrintObj(table, out); err != nil {
+				t.Fatal(err)
+			}
+			if e, a := expected, out.String(); e != a {
+				t.Errorf("expected\n%v\ngot\n%v", e, a)
+			}
+		})
+	}
+}
+
+func TestGetCustomColumnsServerColumns(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+	pods.Items[0].Spec.Containers = []corev1.Container{
+		{Name: "a", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: apiresource.MustParse("100m")}}},
+		{Name: "b", Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: apiresource.MustParse("250m")}}},
+	}
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("includeObject") != "Object" {
+				t.Errorf("expected the objects to be included in the table, got %v", req.URL)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: podTableObjBody(codec, pods.Items...)}, nil
+		}),
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("output", "custom-columns=NAME:.metadata.name,READY:column(Ready),CONTAINERS:count(.spec.containers),CPU:sum(.spec.containers[*].resources.requests.cpu)")
+	cmd.Run(cmd, []string{"pods"})
+
+	expected := `NAME   READY   CONTAINERS   CPU
+foo    0/0     2            350m
+bar    0/0     0            <none>
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
@@ -68,6 +68,11 @@ type GetOptions struct {
 
 	OutputWatchEvents bool
 
+	// ShowChanges prints the fields changed by each modification of the
+	// watched objects, limited to WatchFields when set.
+	ShowChanges bool
+	WatchFields []string
+
 	LabelSelector     string
 	FieldSelector     string
 	AllNamespaces     bool
@@ -76,7 +81,28 @@ type GetOptions struct {
 	Subresource       string
 	SortBy            string
 
+	// Where holds JSONPath predicates the printed objects must match, once
+	// they are listed.
+	Where []string
+	where []wherePredicate
+
 	ServerPrint bool
+	// serverColumns is set when custom columns refer to the columns of the
+	// tables printed on the server side.
+	serverColumns bool
+
+	// GroupBy, Count and Aggregate print a table of the groups of the
+	// listed objects, with their number and the aggregated values of other
+	// columns, instead of the objects.
+	GroupBy   []string
+	Count     bool
+	Aggregate []string
+
+	// Contexts and AllContexts name the contexts of the kubeconfig, or glob
+	// patterns of them, to get the objects from instead of the current one.
+	Contexts    []string
+	AllContexts string
+	contexts    []string
 
 	NoHeaders      bool
 	IgnoreNotFound bool
@@ -94,7 +120,44 @@ var (
 		namespace if you don't specify any namespace.
 
 		By specifying the output as 'template' and providing a Go template as the value
-		of the --template flag, you can filter the attributes of the fetched resources.`))
+		of the --template flag, you can filter the attributes of the fetched resources.
+
+		Besides JSONPath expressions, the custom columns can be computed with age(PATH)
+		and duration(PATH), formatting timestamps and durations, sum(PATH), adding numbers
+		or quantities, count(PATH), counting values and the elements of lists,
+		if(PREDICATE, THEN, ELSE), with a predicate written like the ones of --where and
+		quoted strings or expressions as values, and column(NAME), printing a column of the
+		table printed by the server.
+
+		With --where, only the objects matching JSONPath predicates are printed. A predicate
+		is an expression matching the objects for which it finds a value which is not empty
+		or false, like .status.containerStatuses[?(@.restartCount > 5)], or an expression
+		compared to a value with ==, !=, <, <=, > or >=, like .spec.replicas>=3. The
+		predicates are checked by kubectl once the objects are listed, and on each event
+		when watching. CEL expressions, like self.spec.replicas > 3, are not supported.
+
+		The csv, tsv and markdown output formats print the rows of the same tables, with
+		the columns added by --show-labels and --label-columns, as comma or tab separated
+		values, or as a Markdown table. The ndjson output format prints each object as
+		compact JSON on its own line, without wrapping the objects in a list, which can
+		be streamed with --watch.
+
+		When watching with --show-changes, the fields changed by each modification of an
+		object are printed after it, as path: old → new, optionally limited to the fields
+		given with --watch-fields. This is supported in table and YAML output.
+
+		With --group-by, the objects are grouped by columns of the table, custom columns or
+		JSONPath expressions, and a table of the groups is printed instead, with their number
+		of objects. --count prints the number of objects without grouping them, and
+		--aggregate adds the sum, minimum or maximum of numeric or quantity columns.
+
+		With --contexts or --all-contexts, the objects are listed concurrently in the clusters
+		of several kubeconfig contexts, each using its own namespace unless --namespace is
+		given. The tables of the same resource are merged, with a CONTEXT column, as are the
+		custom columns, while in the other output formats the objects of all the contexts are
+		printed as one list, with their context in the kubectl.kubernetes.io/context
+		annotation. The errors of the contexts which could not be reached are reported after
+		the other objects.`))
 
 	getExample = templates.Examples(i18n.T(`
 		# List all pods in ps output format
@@ -118,12 +181,33 @@ var (
 		# List resources from a directory with kustomization.yaml - e.g. dir/kustomization.yaml
 		kubectl get -k dir/
 
+		# List the pods with a container restarted more than 5 times
+		kubectl get pods --where '.status.containerStatuses[?(@.restartCount > 5)]'
+
+		# Watch the pods which are not running on the node 'node-1'
+		kubectl get pods --watch --where '.spec.nodeName==node-1' --where '.status.phase!=Running'
+
+		# List all pods as comma separated values, with their labels
+		kubectl get pods -o csv --show-labels
+
+		# List the deployments of all namespaces in a Markdown table, sorted by name
+		kubectl get deployments -A -o markdown --sort-by=.metadata.name
+
+		# Watch the pods, printing each change as JSON on its own line
+		kubectl get pods --watch -o ndjson
+
 		# Return only the phase value of the specified pod
 		kubectl get -o template pod/web-pod-13je7 --template={{.status.phase}}
 
 		# List resource information in custom columns
 		kubectl get pod test-pod -o custom-columns=CONTAINER:.spec.containers[0].name,IMAGE:.spec.containers[0].image
 
+		# List the pods with the status printed by the server, their age and the sum of their CPU requests
+		kubectl get pods -o custom-columns='NAME:.metadata.name,STATUS:column(Status),AGE:age(.metadata.creationTimestamp),CPU:sum(.spec.containers[*].resources.requests.cpu)'
+
+		# List the nodes with their number of images, and whether they are cordoned
+		kubectl get nodes -o custom-columns='NAME:.metadata.name,IMAGES:count(.status.images),SCHEDULING:if(.spec.unschedulable, "Disabled", "Enabled")'
+
 		# List all replication controllers and services together in ps output format
 		kubectl get rc,services
 
@@ -137,7 +221,28 @@ var (
 		kubectl get deployments.apps --namespace backend
 
 		# List all pods existing in all namespaces
-		kubectl get pods --all-namespaces`))
+		kubectl get pods --all-namespaces
+
+		# Count the pods by phase in each namespace
+		kubectl get pods --all-namespaces --group-by=.metadata.namespace,status
+
+		# Sum the CPU requests of the pods by node
+		kubectl get pods -A --group-by=.spec.nodeName --aggregate=sum:.spec.containers[*].resources.requests.cpu
+
+		# Count the pods by image, with their custom columns
+		kubectl get pods -o custom-columns=NAME:.metadata.name,IMAGE:.spec.containers[0].image --group-by=IMAGE
+
+		# List the pods of the clusters of the contexts 'staging' and 'prod', with a CONTEXT column
+		kubectl get pods --contexts=staging,prod
+
+		# List the nodes of the clusters of all the contexts whose name starts with 'prod-'
+		kubectl get nodes --all-contexts='prod-*'
+
+		# Watch the deployments, printing the fields changed by each modification
+		kubectl get deployments --watch --show-changes
+
+		# Watch the deployment 'web', printing the changes of its status and replicas only
+		kubectl get deployment web --watch --show-changes --watch-fields=.status,.spec.replicas`))
 )
 
 const (
@@ -182,6 +287,15 @@ func NewCmdGet(parent string, f cmdutil.Factory, streams genericiooptions.IOStre
 	cmd.Flags().BoolVarP(&o.Watch, "watch", "w", o.Watch, "After listing/getting the requested object, watch for changes.")
 	cmd.Flags().BoolVar(&o.WatchOnly, "watch-only", o.WatchOnly, "Watch for changes to the requested object(s), without listing/getting first.")
 	cmd.Flags().BoolVar(&o.OutputWatchEvents, "output-watch-events", o.OutputWatchEvents, "Output watch event objects when --watch or --watch-only is used. Existing objects are output as initial ADDED events.")
+	cmd.Flags().StringSliceVar(&o.GroupBy, "group-by", o.GroupBy, "Comma separated list of columns, or JSONPath expressions like .spec.nodeName, to group the objects by. A table of the groups is printed instead of the objects, with their number of objects.")
+	cmd.Flags().BoolVar(&o.Count, "count", o.Count, "If true, print the number of objects instead of the objects, for each group with --group-by.")
+	cmd.Flags().StringSliceVar(&o.Aggregate, "aggregate", o.Aggregate, "Comma separated list of FUNCTION:COLUMN, with FUNCTION one of sum, min or max, to aggregate numeric or quantity columns, or JSONPath expressions, for each group. Requires --group-by or --count.")
+	cmd.Flags().StringSliceVar(&o.Contexts, "contexts", o.Contexts, "Comma separated list of kubeconfig contexts, or glob patterns of contexts, to get the objects from concurrently. A CONTEXT column is added to the tables and to the custom columns.")
+	cmd.Flags().StringVar(&o.AllContexts, "all-contexts", o.AllContexts, "If present, get the objects from all the kubeconfig contexts, or from the ones matching the given glob pattern, like --all-contexts='prod-*'.")
+	cmd.Flags().Lookup("all-contexts").NoOptDefVal = "*"
+	cmd.Flags().BoolVar(&o.ShowChanges, "show-changes", o.ShowChanges, "When used with --watch or --watch-only, print the fields changed by each modification of an object, as path: old → new, after the object.")
+	cmd.Flags().StringSliceVar(&o.WatchFields, "watch-fields", o.WatchFields, "Comma separated list of fields, like .status,.spec.replicas, to limit the changes printed by --show-changes to.")
+	cmd.Flags().StringArrayVar(&o.Where, "where", o.Where, "Print only the objects matching this JSONPath predicate, like '.status.phase!=Running' or '.status.containerStatuses[?(@.restartCount > 5)]', checked after the objects are listed and on each watch event. May be repeated, the objects must match all of them. CEL expressions are not supported.")
 	cmd.Flags().BoolVar(&o.IgnoreNotFound, "ignore-not-found", o.IgnoreNotFound, "If set to true, suppresses NotFound error for specific objects that do not exist. Using this flag with commands that query for collections of resources has no effect when no resources are found.")
 	cmd.Flags().StringVar(&o.FieldSelector, "field-selector", o.FieldSelector, "Selector (field query) to filter on, supports '=', '==', and '!='.(e.g. --field-selector key1=value1,key2=value2). The server only supports a limited number of field queries per type.")
 	cmd.Flags().BoolVarP(&o.AllNamespaces, "all-namespaces", "A", o.AllNamespaces, "If present, list the requested object(s) across all namespaces. Namespace in current context is ignored even if specified with --namespace.")
@@ -215,12 +329,25 @@ func (o *GetOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []stri
 		o.SortBy = *o.PrintFlags.HumanReadableFlags.SortBy
 	}
 
+	o.where, err = parseWherePredicates(o.Where)
+	if err != nil {
+		return err
+	}
+
+	if err := o.completeContexts(f); err != nil {
+		return err
+	}
+
 	o.NoHeaders = cmdutil.GetFlagBool(cmd, "no-headers")
 
-	// TODO (soltysh): currently we don't support custom columns
-	// with server side print. So in these cases force the old behavior.
+	// Custom columns are printed from the tables printed on the server side
+	// only when they refer to their columns. So in the other cases force the
+	// old behavior.
 	outputOption := cmd.Flags().Lookup("output").Value.String()
-	if strings.Contains(outputOption, "custom-columns") || outputOption == "yaml" || strings.Contains(outputOption, "json") {
+	if strings.Contains(outputOption, "custom-columns") && o.ServerPrint && o.customColumnsUseServerColumns() {
+		o.serverColumns = true
+		o.IsHumanReadablePrinter = true
+	} else if strings.Contains(outputOption, "custom-columns") || outputOption == "yaml" || strings.Contains(outputOption, "json") {
 		o.ServerPrint = false
 	}
 
@@ -230,7 +357,8 @@ func (o *GetOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []stri
 	}
 
 	// human readable printers have special conversion rules, so we determine if we're using one.
-	if (len(*o.PrintFlags.OutputFormat) == 0 && len(templateArg) == 0) || *o.PrintFlags.OutputFormat == "wide" {
+	// The tabular formats print the same tables as text.
+	if (len(*o.PrintFlags.OutputFormat) == 0 && len(templateArg) == 0) || *o.PrintFlags.OutputFormat == "wide" || isTabularFormat(*o.PrintFlags.OutputFormat) {
 		o.IsHumanReadablePrinter = true
 	}
 
@@ -252,6 +380,9 @@ func (o *GetOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []stri
 		if err != nil {
 			return nil, err
 		}
+		if customColumns, ok := printer.(*CustomColumnsPrinter); ok && len(o.contexts) > 0 {
+			customColumns.Columns = append([]Column{contextColumn}, customColumns.Columns...)
+		}
 		printer, err = printers.NewTypeSetter(scheme.Scheme).WrapToPrinter(printer, nil)
 		if err != nil {
 			return nil, err
@@ -263,6 +394,9 @@ func (o *GetOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []stri
 		if outputObjects != nil {
 			printer = &skipPrinter{delegate: printer, output: outputObjects}
 		}
+		if len(o.where) > 0 {
+			printer = &wherePrinter{delegate: printer, predicates: o.where}
+		}
 		if o.ServerPrint {
 			printer = &TablePrinter{Delegate: printer}
 		}
@@ -294,10 +428,29 @@ func (o *GetOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []stri
 	return nil
 }
 
+// customColumnsUseServerColumns returns whether the custom columns refer to
+// the columns of the tables printed on the server side.
+func (o *GetOptions) customColumnsUseServerColumns() bool {
+	printFlags := o.PrintFlags.Copy()
+	printer, err := printFlags.ToPrinter()
+	if err != nil {
+		// the error is reported when printing
+		return false
+	}
+	customColumns, ok := printer.(*CustomColumnsPrinter)
+	return ok && customColumns.usesServerColumns()
+}
+
+// aggregating returns whether the groups of the objects are printed instead
+// of the objects.
+func (o *GetOptions) aggregating() bool {
+	return len(o.GroupBy) > 0 || o.Count
+}
+
 // Validate checks the set of flags provided by the user.
 func (o *GetOptions) Validate() error {
 	if len(o.Raw) > 0 {
-		if o.Watch || o.WatchOnly || len(o.LabelSelector) > 0 {
+		if o.Watch || o.WatchOnly || len(o.LabelSelector) > 0 || len(o.Where) > 0 {
 			return fmt.Errorf("--raw may not be specified with other flags that filter the server request or alter the output")
 		}
 		if o.PrintFlags.OutputFormat != nil && len(*o.PrintFlags.OutputFormat) > 0 {
@@ -309,7 +462,7 @@ func (o *GetOptions) Validate() error {
 	}
 	if o.PrintFlags.HumanReadableFlags.ShowLabels != nil && *o.PrintFlags.HumanReadableFlags.ShowLabels && o.PrintFlags.OutputFormat != nil {
 		outputOption := *o.PrintFlags.OutputFormat
-		if outputOption != "" && outputOption != "wide" {
+		if outputOption != "" && outputOption != "wide" && !isTabularFormat(outputOption) {
 			return fmt.Errorf("--show-labels option cannot be used with %s printer", outputOption)
 		}
 	}
@@ -322,6 +475,43 @@ func (o *GetOptions) Validate() error {
 	if o.OutputWatchEvents && !(o.Watch || o.WatchOnly) {
 		return fmt.Errorf("--output-watch-events option can only be used with --watch or --watch-only")
 	}
+	if len(o.Contexts) > 0 || len(o.AllContexts) > 0 {
+		if o.Watch || o.WatchOnly {
+			return fmt.Errorf("--contexts and --all-contexts options cannot be used with --watch or --watch-only")
+		}
+		if len(o.Raw) > 0 {
+			return fmt.Errorf("--contexts and --all-contexts options cannot be used with --raw")
+		}
+		if o.aggregating() {
+			return fmt.Errorf("--contexts and --all-contexts options cannot be used with --group-by or --count")
+		}
+	}
+	if o.aggregating() {
+		if o.Watch || o.WatchOnly {
+			return fmt.Errorf("--group-by and --count options cannot be used with --watch or --watch-only")
+		}
+		if o.PrintFlags.OutputFormat != nil {
+			if outputOption := *o.PrintFlags.OutputFormat; outputOption != "" && outputOption != "wide" && !strings.HasPrefix(outputOption, "custom-columns") {
+				return fmt.Errorf("--group-by and --count options cannot be used with %s printer", outputOption)
+			}
+		}
+		if _, err := parseAggregations(o.Aggregate); err != nil {
+			return err
+		}
+	} else if len(o.Aggregate) > 0 {
+		return fmt.Errorf("--aggregate option can only be used with --group-by or --count")
+	}
+	if o.ShowChanges && !(o.Watch || o.WatchOnly) {
+		return fmt.Errorf("--show-changes option can only be used with --watch or --watch-only")
+	}
+	if len(o.WatchFields) > 0 && !o.ShowChanges {
+		return fmt.Errorf("--watch-fields option can only be used with --show-changes")
+	}
+	if o.ShowChanges && o.PrintFlags.OutputFormat != nil {
+		if outputOption := *o.PrintFlags.OutputFormat; outputOption != "" && outputOption != "wide" && outputOption != "yaml" {
+			return fmt.Errorf("--show-changes option cannot be used with %s printer", outputOption)
+		}
+	}
 	return nil
 }
 
@@ -438,8 +628,8 @@ func (o *GetOptions) transformRequests(req *rest.Request) {
 		"application/json",
 	}, ","))
 
-	// if sorting, ensure we receive the full object in order to introspect its fields via jsonpath
-	if len(o.SortBy) > 0 {
+	// if sorting, filtering, showing changes, aggregating or printing custom columns, ensure we receive the full object in order to introspect its fields
+	if len(o.SortBy) > 0 || len(o.Where) > 0 || o.ShowChanges || o.aggregating() || o.serverColumns {
 		req.Param("includeObject", "Object")
 	}
 }
@@ -457,6 +647,9 @@ func (o *GetOptions) Run(f cmdutil.Factory, args []string) error {
 	if o.Watch || o.WatchOnly {
 		return o.watch(f, args)
 	}
+	if len(o.contexts) > 0 {
+		return o.runContexts(f, args)
+	}
 
 	chunkSize := o.ChunkSize
 	if len(o.SortBy) > 0 {
@@ -487,16 +680,26 @@ func (o *GetOptions) Run(f cmdutil.Factory, args []string) error {
 		return err
 	}
 
+	if o.aggregating() {
+		return o.printAggregated(r)
+	}
 	if !o.IsHumanReadablePrinter {
 		return o.printGeneric(r)
 	}
 
 	allErrs := []error{}
-	errs := sets.New[string]()
 	infos, err := r.Infos()
 	if err != nil {
 		allErrs = append(allErrs, err)
 	}
+	return o.printHumanReadable(infos, allErrs)
+}
+
+// printHumanReadable prints the objects in tables, one for each resource,
+// followed by the errors met while getting them.
+func (o *GetOptions) printHumanReadable(infos []*resource.Info, allErrs []error) error {
+	var err error
+	errs := sets.New[string]()
 	printWithKind := multipleGVKsRequested(infos)
 
 	objs := make([]runtime.Object, len(infos))
@@ -522,6 +725,11 @@ func (o *GetOptions) Run(f cmdutil.Factory, args []string) error {
 	separatorWriter := &separatorWriterWrapper{Delegate: trackingWriter}
 
 	w := printers.GetNewTabWriter(separatorWriter)
+	// the columns of the tabular formats are not aligned
+	var out io.Writer = w
+	if isTabularFormat(*o.PrintFlags.OutputFormat) {
+		out = separatorWriter
+	}
 	allResourcesNamespaced := !o.AllNamespaces
 	for ix := range objs {
 		var mapping *meta.RESTMapping
@@ -566,7 +774,7 @@ func (o *GetOptions) Run(f cmdutil.Factory, args []string) error {
 			lastMapping = mapping
 		}
 
-		printer.PrintObj(info.Object, w)
+		printer.PrintObj(info.Object, out)
 	}
 	w.Flush()
 	if trackingWriter.Written == 0 && !o.IgnoreNotFound && len(allErrs) == 0 {
@@ -636,6 +844,19 @@ func (o *GetOptions) watch(f cmdutil.Factory, args []string) error {
 		return err
 	}
 
+	var tracker *changeTracker
+	changesPrefix := "    "
+	if o.ShowChanges {
+		tracker, err = newChangeTracker(o.WatchFields)
+		if err != nil {
+			return err
+		}
+		if *o.PrintFlags.OutputFormat == "yaml" {
+			// changes are printed as comments, after each document
+			changesPrefix = "# "
+		}
+	}
+
 	info := infos[0]
 	mapping := info.ResourceMapping()
 	outputObjects := ptr.To(!o.WatchOnly)
@@ -664,6 +885,11 @@ func (o *GetOptions) watch(f cmdutil.Factory, args []string) error {
 	}
 
 	writer := printers.GetNewTabWriter(o.Out)
+	// the columns of the tabular formats are not aligned
+	var out io.Writer = writer
+	if isTabularFormat(*o.PrintFlags.OutputFormat) {
+		out = o.Out
+	}
 
 	// print the current object
 	var objsToPrint []runtime.Object
@@ -676,10 +902,15 @@ func (o *GetOptions) watch(f cmdutil.Factory, args []string) error {
 		if o.OutputWatchEvents {
 			objToPrint = &metav1.WatchEvent{Type: string(watch.Added), Object: runtime.RawExtension{Object: objToPrint}}
 		}
-		if err := printer.PrintObj(objToPrint, writer); err != nil {
+		if err := printer.PrintObj(objToPrint, out); err != nil {
 			return fmt.Errorf("unable to output the provided object: %v", err)
 		}
 	}
+	if tracker != nil {
+		for _, objToTrack := range objsToPrint {
+			tracker.remember(objToTrack)
+		}
+	}
 	writer.Flush()
 	if isList {
 		// we can start outputting objects now, watches started from lists don't emit synthetic added events
@@ -704,9 +935,26 @@ func (o *GetOptions) watch(f cmdutil.Factory, args []string) error {
 			if o.OutputWatchEvents {
 				objToPrint = &metav1.WatchEvent{Type: string(e.Type), Object: runtime.RawExtension{Object: objToPrint}}
 			}
-			if err := printer.PrintObj(objToPrint, writer); err != nil {
+			if err := printer.PrintObj(objToPrint, out); err != nil {
 				return false, err
 			}
+			if tracker != nil {
+				switch e.Type {
+				case watch.Modified:
+					changes := tracker.changes(e.Object)
+					if len(o.where) > 0 && !watchedObjectsMatchWhere(o.where, e.Object) {
+						// the object was not printed
+						changes = nil
+					}
+					if err := printChanges(changes, changesPrefix, writer); err != nil {
+						return false, err
+					}
+				case watch.Deleted:
+					tracker.forget(e.Object)
+				default:
+					tracker.remember(e.Object)
+				}
+			}
 			writer.Flush()
 			// after processing at least one event, start outputting objects
 			*outputObjects = true
@@ -731,7 +979,12 @@ func (o *GetOptions) printGeneric(r *resource.Result) error {
 		}
 		errs = append(errs, err)
 	}
+	return o.printObjects(infos, singleItemImplied, errs)
+}
 
+// printObjects prints the objects, as a list unless a single item was
+// requested, followed by the errors met while getting them.
+func (o *GetOptions) printObjects(infos []*resource.Info, singleItemImplied bool, errs []error) error {
 	if len(infos) == 0 && o.IgnoreNotFound {
 		return utilerrors.Reduce(utilerrors.Flatten(utilerrors.NewAggregate(errs)))
 	}
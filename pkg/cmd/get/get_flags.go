@@ -32,6 +32,7 @@ import (
 // used in the Get command.
 type PrintFlags struct {
 	JSONYamlPrintFlags *genericclioptions.JSONYamlPrintFlags
+	NDJSONPrintFlags   *NDJSONPrintFlags
 	NamePrintFlags     *genericclioptions.NamePrintFlags
 	CustomColumnsFlags *CustomColumnsPrintFlags
 	HumanReadableFlags *HumanPrintFlags
@@ -67,6 +68,7 @@ func (f *PrintFlags) Copy() PrintFlags {
 // AllowedFormats is the list of formats in which data can be displayed
 func (f *PrintFlags) AllowedFormats() []string {
 	formats := f.JSONYamlPrintFlags.AllowedFormats()
+	formats = append(formats, f.NDJSONPrintFlags.AllowedFormats()...)
 	formats = append(formats, f.NamePrintFlags.AllowedFormats()...)
 	formats = append(formats, f.TemplateFlags.AllowedFormats()...)
 	formats = append(formats, f.CustomColumnsFlags.AllowedFormats()...)
@@ -106,6 +108,10 @@ func (f *PrintFlags) ToPrinter() (printers.ResourcePrinter, error) {
 		return p, err
 	}
 
+	if p, err := f.NDJSONPrintFlags.ToPrinter(outputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
+		return p, err
+	}
+
 	if p, err := f.HumanReadableFlags.ToPrinter(outputFormat); !genericclioptions.IsNoCompatiblePrinterError(err) {
 		return p, err
 	}
@@ -161,6 +167,7 @@ func NewGetPrintFlags() *PrintFlags {
 		NoHeaders:    &noHeaders,
 
 		JSONYamlPrintFlags: genericclioptions.NewJSONYamlPrintFlags(),
+		NDJSONPrintFlags:   NewNDJSONPrintFlags(),
 		NamePrintFlags:     genericclioptions.NewNamePrintFlags(""),
 		TemplateFlags:      genericclioptions.NewKubeTemplatePrintFlags(),
 
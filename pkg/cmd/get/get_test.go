@@ -35,6 +35,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	extensionsv1beta1 "k8s.io/api/extensions/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	metav1beta1 "k8s.io/apimachinery/pkg/apis/meta/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/serializer/streaming"
@@ -53,6 +54,23 @@ var (
 	enableServiceLinks = corev1.DefaultEnableServiceLinks
 )
 
+// testPod returns the pod foo of cmdtesting.TestData, renamed and changed by
+// the given functions, as the unstructured object received from the server.
+func testPod(t *testing.T, name string, changes ...func(pod *corev1.Pod)) *unstructured.Unstructured {
+	pods, _, _ := cmdtesting.TestData()
+	pod := &pods.Items[0]
+	pod.TypeMeta = metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"}
+	pod.Name = name
+	for _, change := range changes {
+		change(pod)
+	}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
 func testComponentStatusData() *corev1.ComponentStatusList {
 	good := corev1.ComponentStatus{
 		Conditions: []corev1.ComponentCondition{
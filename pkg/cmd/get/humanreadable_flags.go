@@ -60,13 +60,13 @@ func (f *HumanPrintFlags) EnsureWithNamespace() error {
 
 // AllowedFormats returns more customized formatting options
 func (f *HumanPrintFlags) AllowedFormats() []string {
-	return []string{"wide"}
+	return []string{"wide", tabularCSV, tabularTSV, tabularMarkdown}
 }
 
 // ToPrinter receives an outputFormat and returns a printer capable of
 // handling human-readable output.
 func (f *HumanPrintFlags) ToPrinter(outputFormat string) (printers.ResourcePrinter, error) {
-	if len(outputFormat) > 0 && outputFormat != "wide" {
+	if len(outputFormat) > 0 && outputFormat != "wide" && !isTabularFormat(outputFormat) {
 		return nil, genericclioptions.NoCompatiblePrinterError{Options: f, AllowedFormats: f.AllowedFormats()}
 	}
 
@@ -85,7 +85,7 @@ func (f *HumanPrintFlags) ToPrinter(outputFormat string) (printers.ResourcePrint
 		columnLabels = *f.ColumnLabels
 	}
 
-	p := printers.NewTablePrinter(printers.PrintOptions{
+	options := printers.PrintOptions{
 		Kind:          f.Kind,
 		WithKind:      showKind,
 		NoHeaders:     f.NoHeaders,
@@ -93,7 +93,11 @@ func (f *HumanPrintFlags) ToPrinter(outputFormat string) (printers.ResourcePrint
 		WithNamespace: f.WithNamespace,
 		ColumnLabels:  columnLabels,
 		ShowLabels:    showLabels,
-	})
+	}
+	if isTabularFormat(outputFormat) {
+		return NewTabularPrinter(outputFormat, options), nil
+	}
+	p := printers.NewTablePrinter(options)
 
 	// TODO(juanvallejo): handle sorting here
 
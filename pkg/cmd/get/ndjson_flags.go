@@ -0,0 +1,46 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// NDJSONPrintFlags provides the flags necessary for printing objects as
+// newline delimited JSON.
+type NDJSONPrintFlags struct{}
+
+// AllowedFormats returns the output formats of newline delimited JSON.
+func (f *NDJSONPrintFlags) AllowedFormats() []string {
+	return []string{"ndjson"}
+}
+
+// ToPrinter receives an outputFormat and returns a printer capable of
+// printing objects as newline delimited JSON.
+func (f *NDJSONPrintFlags) ToPrinter(outputFormat string) (printers.ResourcePrinter, error) {
+	if outputFormat != "ndjson" {
+		return nil, genericclioptions.NoCompatiblePrinterError{OutputFormat: &outputFormat, AllowedFormats: f.AllowedFormats()}
+	}
+	return &NDJSONPrinter{}, nil
+}
+
+// NewNDJSONPrintFlags returns flags associated with newline delimited JSON
+// printing.
+func NewNDJSONPrintFlags() *NDJSONPrintFlags {
+	return &NDJSONPrintFlags{}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+func TestNDJSONPrinter(t *testing.T) {
+	pod := func(name string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "Pod",
+			"metadata":   map[string]interface{}{"name": name},
+		}}
+	}
+	list := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{"apiVersion": "v1", "kind": "List"},
+		Items:  []unstructured.Unstructured{pod("foo"), pod("bar")},
+	}
+	bar := pod("bar")
+	event := &metav1.WatchEvent{Type: "DELETED", Object: runtime.RawExtension{Object: &bar}}
+
+	out := &strings.Builder{}
+	printer := &NDJSONPrinter{}
+	for _, obj := range []runtime.Object{list, &unstructured.UnstructuredList{Object: map[string]interface{}{"apiVersion": "v1", "kind": "List"}}, event} {
+		if err := printer.PrintObj(obj, out); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := `{"apiVersion":"v1","kind":"Pod","metadata":{"name":"foo"}}
+{"apiVersion":"v1","kind":"Pod","metadata":{"name":"bar"}}
+{"type":"DELETED","object":{"apiVersion":"v1","kind":"Pod","metadata":{"name":"bar"}}}
+`
+	if e, a := expected, out.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestGetNDJSON(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Resp:                 &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.ObjBody(codec, pods)},
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("output", "ndjson")
+	cmd.Run(cmd, []string{"pods"})
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	var names []string
+	for _, line := range lines {
+		obj := &unstructured.Unstructured{}
+		if err := json.Unmarshal([]byte(line), &obj.Object); err != nil {
+			t.Fatalf("expected an object on each line, got %q: %v", line, err)
+		}
+		names = append(names, obj.GetKind()+"/"+obj.GetName())
+	}
+	if e, a := "Pod/foo,Pod/bar", strings.Join(names, ","); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
@@ -60,6 +60,10 @@ func decodeIntoTable(obj runtime.Object) (runtime.Object, error) {
 	if isEvent {
 		obj = event.Object.Object
 	}
+	if table, ok := obj.(*metav1.Table); ok && !isEvent {
+		// already decoded
+		return table, nil
+	}
 
 	if !recognizedTableVersions[obj.GetObjectKind().GroupVersionKind()] {
 		return nil, fmt.Errorf("attempt to decode non-Table object")
@@ -92,3 +96,21 @@ func decodeIntoTable(obj runtime.Object) (runtime.Object, error) {
 	}
 	return table, nil
 }
+
+// withContextColumn returns the table of obj with a first CONTEXT column,
+// holding the kubeconfig context the rows were listed in. Objects which are
+// not tables, e.g. with --server-print=false, are printed as a table of their
+// names and ages.
+func withContextColumn(obj runtime.Object, context string) runtime.Object {
+	var table *metav1.Table
+	if decoded, err := decodeIntoTable(obj); err == nil {
+		table = decoded.(*metav1.Table)
+	} else if table, err = objectsTable(obj); err != nil {
+		return obj
+	}
+	table.ColumnDefinitions = append([]metav1.TableColumnDefinition{{Name: "Context", Type: "string", Description: "The kubeconfig context of the object."}}, table.ColumnDefinitions...)
+	for i := range table.Rows {
+		table.Rows[i].Cells = append([]interface{}{context}, table.Rows[i].Cells...)
+	}
+	return table
+}
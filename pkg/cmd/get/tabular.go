@@ -0,0 +1,241 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/cli-runtime/pkg/printers"
+)
+
+// Output formats printing the rows of tables as delimited or Markdown text.
+const (
+	tabularCSV      = "csv"
+	tabularTSV      = "tsv"
+	tabularMarkdown = "markdown"
+)
+
+var tabularFormats = map[string]bool{
+	tabularCSV:      true,
+	tabularTSV:      true,
+	tabularMarkdown: true,
+}
+
+// isTabularFormat returns whether the output format prints the rows of
+// tables as delimited or Markdown text, whose columns must not be aligned.
+func isTabularFormat(outputFormat string) bool {
+	return tabularFormats[outputFormat]
+}
+
+// TabularPrinter prints the rows of tables as CSV, TSV or Markdown. The
+// columns are decorated like the ones of the human-readable printer, with
+// the namespace, kind, labels and label columns of the options.
+type TabularPrinter struct {
+	Format  string
+	Options printers.PrintOptions
+
+	// lastColumns are the columns of the last table, whose headers are
+	// printed again only when they change.
+	lastColumns    []metav1.TableColumnDefinition
+	printedHeaders bool
+}
+
+// NewTabularPrinter returns a printer of tables in the given format.
+func NewTabularPrinter(format string, options printers.PrintOptions) *TabularPrinter {
+	return &TabularPrinter{Format: format, Options: options}
+}
+
+func (p *TabularPrinter) PrintObj(obj runtime.Object, w io.Writer) error {
+	if printers.InternalObjectPreventer.IsForbidden(reflect.Indirect(reflect.ValueOf(obj)).Type().PkgPath()) {
+		return errors.New(printers.InternalObjectPrinterErr)
+	}
+
+	eventType := ""
+	if event, isEvent := obj.(*metav1.WatchEvent); isEvent {
+		eventType = event.Type
+		obj = event.Object.Object
+	}
+	table, isTable := obj.(*metav1.Table)
+	if !isTable {
+		var err error
+		if table, err = objectsTable(obj); err != nil {
+			return err
+		}
+	}
+
+	columns := table.ColumnDefinitions
+	if len(columns) == 0 {
+		// the tables of watch events may omit the columns of the previous ones
+		columns = p.lastColumns
+	} else if !reflect.DeepEqual(columns, p.lastColumns) {
+		p.lastColumns = columns
+		p.printedHeaders = false
+	}
+	if len(table.Rows) == 0 {
+		return nil
+	}
+	withHeaders := !p.Options.NoHeaders && !p.printedHeaders
+	p.printedHeaders = true
+
+	var records [][]string
+	if withHeaders {
+		records = append(records, p.headers(columns, len(eventType) > 0))
+	}
+	for _, row := range table.Rows {
+		records = append(records, p.cells(columns, row, eventType))
+	}
+
+	if p.Format == tabularMarkdown {
+		return printMarkdown(records, withHeaders, w)
+	}
+	writer := csv.NewWriter(w)
+	if p.Format == tabularTSV {
+		writer.Comma = '\t'
+	}
+	return writer.WriteAll(records)
+}
+
+// headers returns the headers of the printed columns.
+func (p *TabularPrinter) headers(columns []metav1.TableColumnDefinition, withEvent bool) []string {
+	var headers []string
+	if withEvent {
+		headers = append(headers, "EVENT")
+	}
+	if p.Options.WithNamespace {
+		headers = append(headers, "NAMESPACE")
+	}
+	for _, column := range columns {
+		if column.Priority != 0 && !p.Options.Wide {
+			continue
+		}
+		headers = append(headers, strings.ToUpper(column.Name))
+	}
+	for _, label := range p.Options.ColumnLabels {
+		parts := strings.Split(label, "/")
+		headers = append(headers, strings.ToUpper(parts[len(parts)-1]))
+	}
+	if p.Options.ShowLabels {
+		headers = append(headers, "LABELS")
+	}
+	return headers
+}
+
+// cells returns the cells of the printed columns of a row.
+func (p *TabularPrinter) cells(columns []metav1.TableColumnDefinition, row metav1.TableRow, eventType string) []string {
+	var namespace string
+	var objectLabels map[string]string
+	if row.Object.Object != nil {
+		if accessor, err := meta.Accessor(row.Object.Object); err == nil {
+			namespace = accessor.GetNamespace()
+			objectLabels = accessor.GetLabels()
+		}
+	}
+
+	var cells []string
+	if len(eventType) > 0 {
+		cells = append(cells, eventType)
+	}
+	if p.Options.WithNamespace {
+		cells = append(cells, namespace)
+	}
+	for i, column := range columns {
+		if column.Priority != 0 && !p.Options.Wide {
+			continue
+		}
+		cell := ""
+		if i < len(row.Cells) && row.Cells[i] != nil {
+			cell = fmt.Sprint(row.Cells[i])
+		}
+		if column.Format == "name" && p.Options.WithKind && !p.Options.Kind.Empty() {
+			cell = strings.ToLower(p.Options.Kind.String()) + "/" + cell
+		}
+		cells = append(cells, cell)
+	}
+	for _, label := range p.Options.ColumnLabels {
+		cells = append(cells, objectLabels[label])
+	}
+	if p.Options.ShowLabels {
+		cells = append(cells, labels.FormatLabels(objectLabels))
+	}
+	return cells
+}
+
+// printMarkdown prints records as the rows of a Markdown table.
+func printMarkdown(records [][]string, withHeaders bool, w io.Writer) error {
+	escaper := strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ")
+	for i, record := range records {
+		cells := make([]string, len(record))
+		for j, cell := range record {
+			cells[j] = escaper.Replace(cell)
+		}
+		if _, err := fmt.Fprintf(w, "| %s |\n", strings.Join(cells, " | ")); err != nil {
+			return err
+		}
+		if i == 0 && withHeaders {
+			if _, err := fmt.Fprintf(w, "|%s\n", strings.Repeat(" --- |", len(record))); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// objectsTable returns a table of the names and ages of objects, when the
+// server did not print them in a table.
+func objectsTable(obj runtime.Object) (*metav1.Table, error) {
+	objs := []runtime.Object{obj}
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return nil, err
+		}
+		objs = items
+	}
+
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Age", Type: "string"},
+		},
+	}
+	for _, item := range objs {
+		accessor, err := meta.Accessor(item)
+		if err != nil {
+			return nil, err
+		}
+		age := "<unknown>"
+		if created := accessor.GetCreationTimestamp(); !created.IsZero() {
+			age = duration.HumanDuration(time.Since(created.Time))
+		}
+		table.Rows = append(table.Rows, metav1.TableRow{
+			Cells:  []interface{}{accessor.GetName(), age},
+			Object: runtime.RawExtension{Object: item},
+		})
+	}
+	return table, nil
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+func newTabularTable() *metav1.Table {
+	row := func(name, ready string, labels map[string]interface{}) metav1.TableRow {
+		return metav1.TableRow{
+			Cells: []interface{}{name, ready, "node1"},
+			Object: runtime.RawExtension{Object: &unstructured.Unstructured{Object: map[string]interface{}{
+				"metadata": map[string]interface{}{"name": name, "namespace": "test", "labels": labels},
+			}}},
+		}
+	}
+	return &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{
+			{Name: "Name", Type: "string", Format: "name"},
+			{Name: "Ready", Type: "string"},
+			{Name: "Node", Type: "string", Priority: 1},
+		},
+		Rows: []metav1.TableRow{
+			row("web-1", "1/1", map[string]interface{}{"app": "web", "example.com/tier": "front"}),
+			row("web-2", "0/1", map[string]interface{}{"app": "web"}),
+		},
+	}
+}
+
+func TestTabularPrinter(t *testing.T) {
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: "csv",
+			expected: `NAMESPACE,NAME,READY,TIER,LABELS
+test,pod/web-1,1/1,front,"app=web,example.com/tier=front"
+test,pod/web-2,0/1,,app=web
+`,
+		},
+		{
+			format: "tsv",
+			expected: "NAMESPACE\tNAME\tREADY\tTIER\tLABELS\n" +
+				"test\tpod/web-1\t1/1\tfront\tapp=web,example.com/tier=front\n" +
+				"test\tpod/web-2\t0/1\t\tapp=web\n",
+		},
+		{
+			format: "markdown",
+			expected: `| NAMESPACE | NAME | READY | TIER | LABELS |
+| --- | --- | --- | --- | --- |
+| test | pod/web-1 | 1/1 | front | app=web,example.com/tier=front |
+| test | pod/web-2 | 0/1 |  | app=web |
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			printer := NewTabularPrinter(tt.format, printers.PrintOptions{
+				Kind:          schema.GroupKind{Kind: "Pod"},
+				WithKind:      true,
+				WithNamespace: true,
+				ColumnLabels:  []string{"example.com/tier"},
+				ShowLabels:    true,
+			})
+			out := &strings.Builder{}
+			if err := printer.PrintObj(newTabularTable(), out); err != nil {
+				t.Fatal(err)
+			}
+			if e, a := tt.expected, out.String(); e != a {
+				t.Errorf("expected\n%v\ngot\n%v", e, a)
+			}
+		})
+	}
+}
+
+func TestTabularPrinterHeaders(t *testing.T) {
+	printer := NewTabularPrinter("csv", printers.PrintOptions{})
+	out := &strings.Builder{}
+
+	// the headers are printed with the first rows, and only again when the
+	// columns change
+	empty := newTabularTable()
+	empty.Rows = nil
+	withoutColumns := newTabularTable()
+	withoutColumns.ColumnDefinitions = nil
+	event := &metav1.WatchEvent{Type: "MODIFIED", Object: runtime.RawExtension{Object: withoutColumns}}
+	for _, obj := range []runtime.Object{empty, newTabularTable(), withoutColumns, event} {
+		if err := printer.PrintObj(obj, out); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := `NAME,READY
+web-1,1/1
+web-2,0/1
+web-1,1/1
+web-2,0/1
+MODIFIED,web-1,1/1
+MODIFIED,web-2,0/1
+`
+	if e, a := expected, out.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestGetTabularSortedTable(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Resp:                 &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: podTableObjBody(codec, pods.Items...)},
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("output", "tsv")
+	cmd.Flags().Set("sort-by", ".metadata.name")
+	cmd.Flags().Set("show-labels", "true")
+	cmd.Run(cmd, []string{"pods"})
+
+	expected := "NAME\tREADY\tSTATUS\tRESTARTS\tAGE\tLABELS\n" +
+		"bar\t0/0\t\t0\t<unknown>\t<none>\n" +
+		"foo\t0/0\t\t0\t<unknown>\t<none>\n"
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
@@ -0,0 +1,294 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// celExpression matches the predicates written as CEL expressions, rooted at
+// self or using CEL macros, which --where does not support.
+var celExpression = regexp.MustCompile(`^\s*self\b|\.(all|exists|exists_one|map|filter)\(|^\s*(has|size)\(`)
+
+// whereOperators are the comparison operators of the predicates, each
+// before the operators it starts with.
+var whereOperators = []string{"==", "!=", ">=", "<=", "=", ">", "<"}
+
+// wherePredicate is a predicate of --where: a JSONPath expression, matching
+// the objects for which it finds a value which is not empty or false, or
+// whose values are compared to a value.
+type wherePredicate struct {
+	parser   *jsonpath.JSONPath
+	operator string
+	value    string
+}
+
+// parseWherePredicates parses predicates written as EXPRESSION, or as
+// EXPRESSION OPERATOR VALUE.
+func parseWherePredicates(specs []string) ([]wherePredicate, error) {
+	var predicates []wherePredicate
+	for _, spec := range specs {
+		if celExpression.MatchString(spec) {
+			return nil, fmt.Errorf("invalid --where predicate %q: CEL expressions are not supported, use a JSONPath expression like .status.containerStatuses[?(@.restartCount > 5)]", spec)
+		}
+		expression, operator, value := splitWherePredicate(spec)
+		expression = strings.TrimSpace(expression)
+		if len(expression) == 0 {
+			return nil, fmt.Errorf("invalid --where predicate %q, expected a JSONPath expression like .status.phase==Running", spec)
+		}
+		field, err := RelaxedJSONPathExpression(expression)
+		if err != nil {
+			return nil, fmt.Errorf("invalid --where predicate %q: %v", spec, err)
+		}
+		parser := jsonpath.New("where").AllowMissingKeys(true)
+		if err := parser.Parse(field); err != nil {
+			return nil, fmt.Errorf("invalid --where predicate %q: %v", spec, err)
+		}
+
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		switch operator {
+		case ">", ">=", "<", "<=":
+			if _, err := apiresource.ParseQuantity(value); err != nil {
+				return nil, fmt.Errorf("invalid --where predicate %q: %q is not a number or a quantity", spec, value)
+			}
+		case "=":
+			operator = "=="
+		}
+		predicates = append(predicates, wherePredicate{parser: parser, operator: operator, value: value})
+	}
+	return predicates, nil
+}
+
+// splitWherePredicate splits a predicate on its first operator which is not
+// in a filter, an index or a quoted string of the expression.
+func splitWherePredicate(spec string) (expression, operator, value string) {
+	depth := 0
+	var quote rune
+	for i, r := range spec {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case strings.ContainsRune("[({", r):
+			depth++
+		case strings.ContainsRune("])}", r):
+			depth--
+		case depth == 0:
+			for _, operator := range whereOperators {
+				if strings.HasPrefix(spec[i:], operator) {
+					return spec[:i], operator, spec[i+len(operator):]
+				}
+			}
+		}
+	}
+	return spec, "", ""
+}
+
+// matches returns whether the predicate matches an object. The objects
+// whose values cannot be compared do not match.
+func (p wherePredicate) matches(obj runtime.Object) bool {
+	results, err := findJSONPathResults(p.parser, obj)
+	if err != nil {
+		return false
+	}
+	var values []reflect.Value
+	for _, result := range results {
+		for _, value := range result {
+			if value, ok := indirectValue(value); ok {
+				values = append(values, value)
+			}
+		}
+	}
+
+	switch p.operator {
+	case "":
+		for _, value := range values {
+			switch value.Kind() {
+			case reflect.Bool:
+				if value.Bool() {
+					return true
+				}
+			case reflect.String, reflect.Slice, reflect.Map, reflect.Array:
+				if value.Len() > 0 {
+					return true
+				}
+			default:
+				return true
+			}
+		}
+		return false
+	case "!=":
+		return !p.equals(values)
+	case "==":
+		return p.equals(values)
+	}
+
+	expected := apiresource.MustParse(p.value)
+	for _, value := range values {
+		q, err := apiresource.ParseQuantity(fmt.Sprint(value.Interface()))
+		if err != nil {
+			continue
+		}
+		switch c := q.Cmp(expected); p.operator {
+		case ">":
+			if c > 0 {
+				return true
+			}
+		case ">=":
+			if c >= 0 {
+				return true
+			}
+		case "<":
+			if c < 0 {
+				return true
+			}
+		case "<=":
+			if c <= 0 {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// equals returns whether one of the values equals the value of the
+// predicate, as a string or as a quantity.
+func (p wherePredicate) equals(values []reflect.Value) bool {
+	expected, err := apiresource.ParseQuantity(p.value)
+	isQuantity := err == nil
+	for _, value := range values {
+		s := fmt.Sprint(value.Interface())
+		if s == p.value {
+			return true
+		}
+		if q, err := apiresource.ParseQuantity(s); err == nil && isQuantity && q.Cmp(expected) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// indirectValue returns the value an interface or a pointer refers to, or
+// false when it is nil.
+func indirectValue(value reflect.Value) (reflect.Value, bool) {
+	for value.Kind() == reflect.Interface || value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return value, false
+		}
+		value = value.Elem()
+	}
+	return value, value.IsValid() && value.CanInterface()
+}
+
+// matchesWhere returns whether an object matches all the predicates.
+func matchesWhere(predicates []wherePredicate, obj runtime.Object) bool {
+	for _, predicate := range predicates {
+		if !predicate.matches(obj) {
+			return false
+		}
+	}
+	return true
+}
+
+// watchedObjectsMatchWhere returns whether one of the objects of a watch
+// event matches all the predicates.
+func watchedObjectsMatchWhere(predicates []wherePredicate, obj runtime.Object) bool {
+	for _, u := range watchedObjects(obj) {
+		if matchesWhere(predicates, u) {
+			return true
+		}
+	}
+	return false
+}
+
+// wherePrinter only prints the objects matching the predicates of --where.
+// The rows of tables are filtered by their objects, as are the items of lists.
+type wherePrinter struct {
+	delegate   printers.ResourcePrinter
+	predicates []wherePredicate
+}
+
+func (p *wherePrinter) PrintObj(obj runtime.Object, writer io.Writer) error {
+	switch t := obj.(type) {
+	case *metav1.Table:
+		return p.delegate.PrintObj(p.filterTable(t), writer)
+	case *metav1.WatchEvent:
+		if table, isTable := t.Object.Object.(*metav1.Table); isTable {
+			event := *t
+			event.Object.Object = p.filterTable(table)
+			return p.delegate.PrintObj(&event, writer)
+		}
+		if !matchesWhere(p.predicates, t.Object.Object) {
+			return nil
+		}
+		return p.delegate.PrintObj(obj, writer)
+	}
+
+	if meta.IsListType(obj) {
+		items, err := meta.ExtractList(obj)
+		if err != nil {
+			return err
+		}
+		var matching []runtime.Object
+		for _, item := range items {
+			if matchesWhere(p.predicates, item) {
+				matching = append(matching, item)
+			}
+		}
+		list := obj.DeepCopyObject()
+		if err := meta.SetList(list, matching); err != nil {
+			return err
+		}
+		return p.delegate.PrintObj(list, writer)
+	}
+
+	if !matchesWhere(p.predicates, obj) {
+		return nil
+	}
+	return p.delegate.PrintObj(obj, writer)
+}
+
+// filterTable returns the table with the rows whose objects match the
+// predicates. The columns are kept, so that they propagate to the delegate
+// even when no row matches.
+func (p *wherePrinter) filterTable(table *metav1.Table) *metav1.Table {
+	filtered := *table
+	filtered.Rows = nil
+	for _, row := range table.Rows {
+		if row.Object.Object != nil && matchesWhere(p.predicates, row.Object.Object) {
+			filtered.Rows = append(filtered.Rows, row)
+		}
+	}
+	return &filtered
+}
@@ -0,0 +1,207 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package get
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiresource "k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest/fake"
+	cmdtesting "k8s.io/kubectl/pkg/cmd/testing"
+	"k8s.io/kubectl/pkg/scheme"
+)
+
+// restartedPod returns a change making a pod run with a container status for
+// each of the given restart counts.
+func restartedPod(restarts ...int32) func(pod *corev1.Pod) {
+	return func(pod *corev1.Pod) {
+		pod.Labels = map[string]string{"app": "web"}
+		pod.Spec.NodeName = "node-1"
+		pod.Spec.Containers = []corev1.Container{{
+			Name:      "web",
+			Resources: corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: apiresource.MustParse("500m")}},
+		}}
+		pod.Status.Phase = corev1.PodRunning
+		for i, restartCount := range restarts {
+			pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses, corev1.ContainerStatus{RestartCount: restartCount, Ready: i == 0})
+		}
+	}
+}
+
+func TestWherePredicates(t *testing.T) {
+	pod := testPod(t, "web", restartedPod(2, 7))
+	tests := []struct {
+		predicate string
+		expected  bool
+	}{
+		{predicate: ".status.phase==Running", expected: true},
+		{predicate: ".status.phase = 'Running'", expected: true},
+		{predicate: "{.status.phase}!=Running", expected: false},
+		{predicate: ".metadata.labels.app==web", expected: true},
+		{predicate: ".status.containerStatuses[?(@.restartCount > 5)]", expected: true},
+		{predicate: ".status.containerStatuses[?(@.restartCount > 10)]", expected: false},
+		{predicate: ".status.containerStatuses[*].restartCount>=7", expected: true},
+		{predicate: ".status.containerStatuses[*].restartCount<2", expected: false},
+		{predicate: ".status.containerStatuses[0].ready", expected: true},
+		{predicate: ".status.containerStatuses[1].ready", expected: false},
+		{predicate: ".spec.containers[0].resources.requests.cpu<1", expected: true},
+		{predicate: ".spec.containers[0].resources.requests.cpu==0.5", expected: true},
+		{predicate: ".spec.nodeName>1", expected: false},
+		{predicate: ".status.podIP", expected: false},
+		{predicate: ".status.podIP!=10.0.0.1", expected: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.predicate, func(t *testing.T) {
+			predicates, err := parseWherePredicates([]string{tt.predicate})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if matches := matchesWhere(predicates, pod); matches != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, matches)
+			}
+		})
+	}
+}
+
+func TestParseWherePredicatesErrors(t *testing.T) {
+	for _, spec := range []string{"", "==Running", "{.status", ".spec.replicas>many"} {
+		if _, err := parseWherePredicates([]string{spec}); err == nil {
+			t.Errorf("expected an error for the predicate %q", spec)
+		}
+	}
+}
+
+func TestParseWherePredicatesCEL(t *testing.T) {
+	for _, spec := range []string{
+		"self.status.containerStatuses.exists(c, c.restartCount > 5)",
+		"self.spec.replicas > 3",
+		".status.containerStatuses.all(c, c.ready)",
+		"size(.spec.containers) > 1",
+	} {
+		_, err := parseWherePredicates([]string{spec})
+		if err == nil || !strings.Contains(err.Error(), "CEL expressions are not supported") {
+			t.Errorf("expected CEL to be rejected for the predicate %q, got %v", spec, err)
+		}
+	}
+}
+
+func TestWherePrinter(t *testing.T) {
+	predicates, err := parseWherePredicates([]string{".status.containerStatuses[?(@.restartCount > 5)]"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	printer := &wherePrinter{delegate: &printers.NamePrinter{}, predicates: predicates}
+
+	table := &metav1.Table{
+		ColumnDefinitions: []metav1.TableColumnDefinition{{Name: "Name", Type: "string", Format: "name"}},
+		Rows: []metav1.TableRow{
+			{Cells: []interface{}{"a"}, Object: runtime.RawExtension{Object: testPod(t, "a", restartedPod(1))}},
+			{Cells: []interface{}{"b"}, Object: runtime.RawExtension{Object: testPod(t, "b", restartedPod(1, 6))}},
+		},
+	}
+	filtered := &strings.Builder{}
+	tablePrinter := &wherePrinter{delegate: printers.NewTablePrinter(printers.PrintOptions{NoHeaders: true}), predicates: predicates}
+	if err := tablePrinter.PrintObj(table, filtered); err != nil {
+		t.Fatal(err)
+	}
+	if e, a := "b\n", filtered.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+
+	list := &unstructured.UnstructuredList{
+		Object: map[string]interface{}{"apiVersion": "v1", "kind": "List"},
+		Items:  []unstructured.Unstructured{*testPod(t, "c", restartedPod(9)), *testPod(t, "d", restartedPod()), *testPod(t, "e", restartedPod(0, 8))},
+	}
+	out := &strings.Builder{}
+	for _, obj := range []runtime.Object{list, testPod(t, "f", restartedPod(3)), testPod(t, "g", restartedPod(30))} {
+		if err := printer.PrintObj(obj, out); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if e, a := "pod/c\npod/e\npod/g\n", out.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestGetWhere(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+			if req.URL.Query().Get("includeObject") != "Object" {
+				t.Errorf("expected the objects to be included in the table, got %v", req.URL)
+			}
+			return &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: podTableObjBody(codec, pods.Items...)}, nil
+		}),
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("where", ".metadata.name!=foo")
+	cmd.Run(cmd, []string{"pods"})
+
+	expected := `NAME   READY   STATUS   RESTARTS   AGE
+bar    0/0              0          <unknown>
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
+
+func TestGetWhereName(t *testing.T) {
+	pods, _, _ := cmdtesting.TestData()
+	pods.Items[1].Status.ContainerStatuses = []corev1.ContainerStatus{{Name: "web", RestartCount: 6}}
+
+	tf := cmdtesting.NewTestFactory().WithNamespace("test")
+	defer tf.Cleanup()
+	codec := scheme.Codecs.LegacyCodec(scheme.Scheme.PrioritizedVersionsAllGroups()...)
+
+	tf.UnstructuredClient = &fake.RESTClient{
+		NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+		Resp:                 &http.Response{StatusCode: http.StatusOK, Header: cmdtesting.DefaultHeader(), Body: cmdtesting.ObjBody(codec, pods)},
+	}
+
+	streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+	cmd := NewCmdGet("kubectl", tf, streams)
+	cmd.SetOut(buf)
+	cmd.SetErr(buf)
+	cmd.Flags().Set("output", "name")
+	cmd.Flags().Set("where", ".status.containerStatuses[?(@.restartCount > 5)]")
+	cmd.Run(cmd, []string{"pods"})
+
+	expected := `pod/bar
+`
+	if e, a := expected, buf.String(); e != a {
+		t.Errorf("expected\n%v\ngot\n%v", e, a)
+	}
+}
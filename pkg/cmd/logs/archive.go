@@ -0,0 +1,319 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+)
+
+const archiveManifestName = "manifest.json"
+
+// ArchiveManifest describes the content of a logs archive written by
+// kubectl logs --archive.
+type ArchiveManifest struct {
+	CreatedAt metav1.Time  `json:"createdAt"`
+	Resource  string       `json:"resource,omitempty"`
+	Selector  string       `json:"selector,omitempty"`
+	Pods      []ArchivePod `json:"pods"`
+}
+
+// ArchivePod describes a pod whose logs are stored in an archive.
+type ArchivePod struct {
+	Namespace  string             `json:"namespace"`
+	Name       string             `json:"name"`
+	UID        string             `json:"uid,omitempty"`
+	NodeName   string             `json:"nodeName,omitempty"`
+	Phase      corev1.PodPhase    `json:"phase,omitempty"`
+	SpecHash   string             `json:"specHash,omitempty"`
+	Containers []ArchiveContainer `json:"containers"`
+}
+
+// ArchiveContainer describes a container whose logs are stored in an archive.
+type ArchiveContainer struct {
+	Name string `json:"name"`
+	// Type is one of "init", "regular" or "ephemeral".
+	Type         string       `json:"type"`
+	RestartCount int32        `json:"restartCount"`
+	Logs         []ArchiveLog `json:"logs"`
+}
+
+// ArchiveLog describes a single log file in an archive. If the logs
+// could not be retrieved, Error holds the reason and Path is empty.
+type ArchiveLog struct {
+	Path      string       `json:"path,omitempty"`
+	Previous  bool         `json:"previous"`
+	Lines     int          `json:"lines"`
+	Bytes     int          `json:"bytes"`
+	FirstTime *metav1.Time `json:"firstTime,omitempty"`
+	LastTime  *metav1.Time `json:"lastTime,omitempty"`
+	Error     string       `json:"error,omitempty"`
+}
+
+type archiveEntry struct {
+	ref      corev1.ObjectReference
+	request  rest.ResponseWrapper
+	previous bool
+}
+
+// runArchive fetches the current and previous logs of all containers of all
+// pods of the object and writes them to a gzipped tar archive at o.Archive,
+// together with a manifest describing the pods and the collected logs.
+func (o LogsOptions) runArchive(ctx context.Context) error {
+	logOptions, ok := o.Options.(*corev1.PodLogOptions)
+	if !ok {
+		return fmt.Errorf("unexpected logs options object")
+	}
+	previousOptions := logOptions.DeepCopy()
+	previousOptions.Previous = true
+
+	current, err := o.AllPodLogsForObject(o.RESTClientGetter, o.Object, logOptions, o.GetPodTimeout, true)
+	if err != nil {
+		return err
+	}
+	previous, err := o.AllPodLogsForObject(o.RESTClientGetter, o.Object, previousOptions, o.GetPodTimeout, true)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]archiveEntry, 0, len(current)+len(previous))
+	for ref, request := range current {
+		entries = append(entries, archiveEntry{ref: ref, request: request})
+	}
+	for ref, request := range previous {
+		entries = append(entries, archiveEntry{ref: ref, request: request, previous: true})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.ref.Namespace != b.ref.Namespace {
+			return a.ref.Namespace < b.ref.Namespace
+		}
+		if a.ref.Name != b.ref.Name {
+			return a.ref.Name < b.ref.Name
+		}
+		if a.ref.FieldPath != b.ref.FieldPath {
+			return a.ref.FieldPath < b.ref.FieldPath
+		}
+		return !a.previous && b.previous
+	})
+
+	file, err := os.Create(o.Archive)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	gzipWriter := gzip.NewWriter(file)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	manifest := &ArchiveManifest{
+		CreatedAt: metav1.Now(),
+		Resource:  o.ResourceArg,
+		Selector:  o.Selector,
+	}
+	var podKeys []string
+	pods := map[string]*ArchivePod{}
+	podObjects := map[string]*corev1.Pod{}
+	containers := 0
+	for _, entry := range entries {
+		key := entry.ref.Namespace + "/" + entry.ref.Name
+		pod, ok := podObjects[key]
+		if !ok {
+			pod, err = o.GetPodFn(ctx, entry.ref.Namespace, entry.ref.Name)
+			if err != nil {
+				return err
+			}
+			archivePod, err := newArchivePod(pod)
+			if err != nil {
+				return err
+			}
+			podObjects[key] = pod
+			pods[key] = archivePod
+			podKeys = append(podKeys, key)
+		}
+
+		containerName := o.containerNameFromRef(entry.ref)
+		container := findArchiveContainer(pods[key], pod, containerName)
+		if container == nil {
+			continue
+		}
+		// Previous logs only exist for containers which have been restarted.
+		if entry.previous && container.RestartCount == 0 {
+			continue
+		}
+		if !entry.previous {
+			containers++
+		}
+
+		archiveLog, data := o.collectArchiveLog(ctx, entry.request)
+		archiveLog.Previous = entry.previous
+		if archiveLog.Error == "" {
+			name := containerName + ".log"
+			if entry.previous {
+				name = containerName + ".previous.log"
+			}
+			archiveLog.Path = path.Join(pod.Namespace, pod.Name, name)
+			if err := writeTarFile(tarWriter, archiveLog.Path, data); err != nil {
+				return err
+			}
+		}
+		container.Logs = append(container.Logs, archiveLog)
+	}
+	for _, key := range podKeys {
+		manifest.Pods = append(manifest.Pods, *pods[key])
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeTarFile(tarWriter, archiveManifestName, append(data, '\n')); err != nil {
+		return err
+	}
+	if err := tarWriter.Close(); err != nil {
+		return err
+	}
+	if err := gzipWriter.Close(); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(o.Out, "Wrote logs of %d container(s) in %d pod(s) to %s\n", containers, len(manifest.Pods), o.Archive)
+	return nil
+}
+
+// collectArchiveLog reads the logs of request and returns their content,
+// with timestamps stripped unless --timestamps was given, along with a
+// description of the collected logs.
+func (o LogsOptions) collectArchiveLog(ctx context.Context, request rest.ResponseWrapper) (ArchiveLog, []byte) {
+	archiveLog := ArchiveLog{}
+	buf := &bytes.Buffer{}
+	splitter := &lineSplitter{
+		emit: func(line logLine) {
+			archiveLog.Lines++
+			if len(line.rawTimestamp) > 0 {
+				t := metav1.NewTime(line.timestamp)
+				if archiveLog.FirstTime == nil {
+					archiveLog.FirstTime = &t
+				}
+				archiveLog.LastTime = &t
+			}
+			_ = o.writeLogLine(buf, line)
+		},
+	}
+	if err := o.ConsumeRequestFn(ctx, request, splitter); err != nil {
+		archiveLog.Error = err.Error()
+		return archiveLog, nil
+	}
+	splitter.Flush()
+	archiveLog.Bytes = buf.Len()
+	return archiveLog, buf.Bytes()
+}
+
+func newArchivePod(pod *corev1.Pod) (*ArchivePod, error) {
+	spec, err := json.Marshal(pod.Spec)
+	if err != nil {
+		return nil, err
+	}
+	hash := sha256.Sum256(spec)
+	return &ArchivePod{
+		Namespace: pod.Namespace,
+		Name:      pod.Name,
+		UID:       string(pod.UID),
+		NodeName:  pod.Spec.NodeName,
+		Phase:     pod.Status.Phase,
+		SpecHash:  "sha256:" + hex.EncodeToString(hash[:]),
+	}, nil
+}
+
+// findArchiveContainer returns the entry of the named container in
+// archivePod, adding it if needed, or nil if the pod has no such container.
+func findArchiveContainer(archivePod *ArchivePod, pod *corev1.Pod, name string) *ArchiveContainer {
+	for i := range archivePod.Containers {
+		if archivePod.Containers[i].Name == name {
+			return &archivePod.Containers[i]
+		}
+	}
+
+	var containerType string
+	var statuses []corev1.ContainerStatus
+	switch {
+	case hasContainer(pod.Spec.InitContainers, name):
+		containerType, statuses = "init", pod.Status.InitContainerStatuses
+	case hasContainer(pod.Spec.Containers, name):
+		containerType, statuses = "regular", pod.Status.ContainerStatuses
+	default:
+		found := false
+		for _, c := range pod.Spec.EphemeralContainers {
+			if c.Name == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+		containerType, statuses = "ephemeral", pod.Status.EphemeralContainerStatuses
+	}
+
+	container := ArchiveContainer{Name: name, Type: containerType}
+	for _, status := range statuses {
+		if status.Name == name {
+			container.RestartCount = status.RestartCount
+		}
+	}
+	archivePod.Containers = append(archivePod.Containers, container)
+	return &archivePod.Containers[len(archivePod.Containers)-1]
+}
+
+func hasContainer(containers []corev1.Container, name string) bool {
+	for _, c := range containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+func writeTarFile(tw *tar.Writer, name string, data []byte) error {
+	hdr := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	_, err := tw.Write(data)
+	return err
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	restclient "k8s.io/client-go/rest"
+)
+
+func TestArchive(t *testing.T) {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-1", Namespace: "test", UID: "1234"},
+		Spec: corev1.PodSpec{
+			NodeName:       "node-1",
+			InitContainers: []corev1.Container{{Name: "setup"}},
+			Containers:     []corev1.Container{{Name: "app"}},
+		},
+		Status: corev1.PodStatus{
+			Phase:                 corev1.PodRunning,
+			InitContainerStatuses: []corev1.ContainerStatus{{Name: "setup"}},
+			ContainerStatuses:     []corev1.ContainerStatus{{Name: "app", RestartCount: 2}},
+		},
+	}
+
+	allPodLogsForObject := func(restClientGetter genericclioptions.RESTClientGetter, object, options runtime.Object, timeout time.Duration, allContainers bool) (map[corev1.ObjectReference]restclient.ResponseWrapper, error) {
+		if !allContainers {
+			t.Errorf("expected logs of all containers to be requested")
+		}
+		setupRef := corev1.ObjectReference{Kind: "Pod", Namespace: "test", Name: "web-1", FieldPath: "spec.initContainers{setup}"}
+		appRef := corev1.ObjectReference{Kind: "Pod", Namespace: "test", Name: "web-1", FieldPath: "spec.containers{app}"}
+		if options.(*corev1.PodLogOptions).Previous {
+			return map[corev1.ObjectReference]restclient.ResponseWrapper{
+				setupRef: &responseWrapperMock{err: errors.New("previous terminated container not found")},
+				appRef:   &responseWrapperMock{data: strings.NewReader("2024-08-30T05:00:00Z crashed\n")},
+			}, nil
+		}
+		return map[corev1.ObjectReference]restclient.ResponseWrapper{
+			setupRef: &responseWrapperMock{data: strings.NewReader("2024-08-30T06:00:00Z setting up\n")},
+			appRef:   &responseWrapperMock{data: strings.NewReader("2024-08-30T06:00:01Z started\n2024-08-30T06:00:02Z serving\n")},
+		}, nil
+	}
+
+	archive := filepath.Join(t.TempDir(), "logs.tar.gz")
+	streams, _, out, _ := genericiooptions.NewTestIOStreams()
+	mock := &logTestMock{}
+	o := NewLogsOptions(streams)
+	o.Archive = archive
+	o.ResourceArg = "pod/web-1"
+	o.Object = pod
+	o.Options = &corev1.PodLogOptions{Timestamps: true}
+	o.AllPodLogsForObject = allPodLogsForObject
+	o.ConsumeRequestFn = mock.mockConsumeRequest
+	o.GetPodFn = func(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+		return pod, nil
+	}
+
+	if err := o.RunLogsContext(t.Context()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if e, a := "Wrote logs of 2 container(s) in 1 pod(s) to "+archive+"\n", out.String(); e != a {
+		t.Errorf("expected output %q, got %q", e, a)
+	}
+
+	files := readTarGz(t, archive)
+	expectedFiles := map[string]string{
+		"test/web-1/app.log":          "started\nserving\n",
+		"test/web-1/app.previous.log": "crashed\n",
+		"test/web-1/setup.log":        "setting up\n",
+	}
+	for name, content := range expectedFiles {
+		if files[name] != content {
+			t.Errorf("expected %s to contain %q, got %q", name, content, files[name])
+		}
+	}
+
+	manifest := &ArchiveManifest{}
+	if err := json.Unmarshal([]byte(files[archiveManifestName]), manifest); err != nil {
+		t.Fatalf("unable to read manifest: %v", err)
+	}
+	if len(manifest.Pods) != 1 {
+		t.Fatalf("expected one pod in manifest, got %d", len(manifest.Pods))
+	}
+	p := manifest.Pods[0]
+	if p.NodeName != "node-1" || p.UID != "1234" || p.Phase != corev1.PodRunning || !strings.HasPrefix(p.SpecHash, "sha256:") {
+		t.Errorf("unexpected pod in manifest: %#v", p)
+	}
+
+	first := metav1.NewTime(time.Date(2024, 8, 30, 6, 0, 1, 0, time.UTC))
+	last := metav1.NewTime(time.Date(2024, 8, 30, 6, 0, 2, 0, time.UTC))
+	previous := metav1.NewTime(time.Date(2024, 8, 30, 5, 0, 0, 0, time.UTC))
+	setup := metav1.NewTime(time.Date(2024, 8, 30, 6, 0, 0, 0, time.UTC))
+	expectedContainers := []ArchiveContainer{
+		{
+			Name:         "app",
+			Type:         "regular",
+			RestartCount: 2,
+			Logs: []ArchiveLog{
+				{Path: "test/web-1/app.log", Lines: 2, Bytes: 16, FirstTime: &first, LastTime: &last},
+				{Path: "test/web-1/app.previous.log", Previous: true, Lines: 1, Bytes: 8, FirstTime: &previous, LastTime: &previous},
+			},
+		},
+		{
+			Name: "setup",
+			Type: "init",
+			Logs: []ArchiveLog{
+				{Path: "test/web-1/setup.log", Lines: 1, Bytes: 11, FirstTime: &setup, LastTime: &setup},
+			},
+		},
+	}
+	if diff := cmp.Diff(expectedContainers, p.Containers); diff != "" {
+		t.Errorf("unexpected containers in manifest (-want +got):\n%s", diff)
+	}
+}
+
+func TestValidateArchiveOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		follow      bool
+		previous    bool
+		expectedErr string
+	}{
+		{
+			name:        "archive with follow",
+			follow:      true,
+			expectedErr: "--archive cannot be used with --follow",
+		},
+		{
+			name:        "archive with previous",
+			previous:    true,
+			expectedErr: "--archive cannot be used with --previous, previous logs are always included in the archive",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			o := NewLogsOptions(genericiooptions.NewTestIOStreamsDiscard())
+			o.Archive = "logs.tar.gz"
+			o.Follow = test.follow
+			o.Previous = test.previous
+			o.Options = &corev1.PodLogOptions{}
+			err := o.Validate()
+			if err == nil || err.Error() != test.expectedErr {
+				t.Errorf("expected error %q, got %v", test.expectedErr, err)
+			}
+		})
+	}
+}
+
+func readTarGz(t *testing.T, name string) map[string]string {
+	t.Helper()
+	f, err := os.Open(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{}
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		files[hdr.Name] = string(data)
+	}
+	return files
+}
@@ -95,6 +95,12 @@ var (
 		# Display only the most recent 20 lines of output in pod nginx
 		kubectl logs --tail=20 nginx
 
+		# Return snapshot logs from all pods in the deployment nginx, merged into a single stream ordered by timestamp
+		kubectl logs deployment/nginx --all-pods=true --all-containers=true --merge
+
+		# Save current and previous logs of all containers in all pods of the deployment nginx to an archive
+		kubectl logs deployment/nginx --archive=nginx-logs.tar.gz
+
 		# Show all logs from pod nginx written in the last hour
 		kubectl logs --since=1h nginx
 		
@@ -116,6 +122,7 @@ var (
 
 const (
 	defaultPodLogsTimeout = 20 * time.Second
+	defaultMergeBuffer    = 1 * time.Second
 )
 
 type LogsOptions struct {
@@ -146,11 +153,23 @@ type LogsOptions struct {
 	MaxFollowConcurrency   int
 	Prefix                 bool
 
+	// Merge orders the lines from all log sources by their timestamp
+	// before printing them. When following, lines are held for up to
+	// MergeBuffer so that slightly delayed sources can be interleaved.
+	Merge       bool
+	MergeBuffer time.Duration
+
+	// Archive is the path of a gzipped tar archive to write the current
+	// and previous logs of all containers of all pods to, instead of
+	// printing them.
+	Archive string
+
 	Object              runtime.Object
 	GetPodTimeout       time.Duration
 	RESTClientGetter    genericclioptions.RESTClientGetter
 	LogsForObject       polymorphichelpers.LogsForObjectFunc
 	AllPodLogsForObject polymorphichelpers.AllPodLogsForObjectFunc
+	GetPodFn            func(ctx context.Context, namespace, name string) (*corev1.Pod, error)
 
 	genericiooptions.IOStreams
 
@@ -164,6 +183,7 @@ func NewLogsOptions(streams genericiooptions.IOStreams) *LogsOptions {
 		IOStreams:            streams,
 		Tail:                 -1,
 		MaxFollowConcurrency: 5,
+		MergeBuffer:          defaultMergeBuffer,
 
 		containerNameFromRefSpecRegexp: regexp.MustCompile(`spec\.(?:initContainers|containers|ephemeralContainers){(.+)}`),
 	}
@@ -208,6 +228,9 @@ func (o *LogsOptions) AddFlags(cmd *cobra.Command) {
 	cmdutil.AddLabelSelectorFlagVar(cmd, &o.Selector)
 	cmd.Flags().IntVar(&o.MaxFollowConcurrency, "max-log-requests", o.MaxFollowConcurrency, "Specify maximum number of concurrent logs to follow when using by a selector. Defaults to 5.")
 	cmd.Flags().BoolVar(&o.Prefix, "prefix", o.Prefix, "Prefix each log line with the log source (pod name and container name)")
+	cmd.Flags().BoolVar(&o.Merge, "merge", o.Merge, "Merge the logs of all sources into a single stream ordered by timestamp. Timestamps are only printed if --timestamps is set.")
+	cmd.Flags().StringVar(&o.Archive, "archive", o.Archive, "Write the current and previous logs of all containers in all pods to this gzipped tar archive, together with a manifest.json describing the pods and logs.")
+	cmd.Flags().DurationVar(&o.MergeBuffer, "merge-buffer", o.MergeBuffer, "When following with --merge, how long to hold each log line waiting for earlier lines from other sources.")
 }
 
 func (o *LogsOptions) ToLogOptions() (*corev1.PodLogOptions, error) {
@@ -215,7 +238,7 @@ func (o *LogsOptions) ToLogOptions() (*corev1.PodLogOptions, error) {
 		Container:                    o.Container,
 		Follow:                       o.Follow,
 		Previous:                     o.Previous,
-		Timestamps:                   o.Timestamps,
+		Timestamps:                   o.Timestamps || o.Merge || len(o.Archive) > 0,
 		InsecureSkipTLSVerifyBackend: o.InsecureSkipTLSVerifyBackend,
 	}
 
@@ -269,6 +292,14 @@ func (o *LogsOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 		return cmdutil.UsageErrorf(cmd, "%s", logsUsageErrStr)
 	}
 
+	if len(o.Archive) > 0 {
+		if len(o.Container) > 0 {
+			return cmdutil.UsageErrorf(cmd, "--archive always includes all containers, a container name must not be specified")
+		}
+		o.AllPods = true
+		o.AllContainers = true
+	}
+
 	if o.AllPods {
 		o.Prefix = true
 	}
@@ -294,6 +325,13 @@ func (o *LogsOptions) Complete(f cmdutil.Factory, cmd *cobra.Command, args []str
 	o.RESTClientGetter = f
 	o.LogsForObject = polymorphichelpers.LogsForObjectFn
 	o.AllPodLogsForObject = polymorphichelpers.AllPodLogsForObjectFn
+	o.GetPodFn = func(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+		clientset, err := f.KubernetesClientSet()
+		if err != nil {
+			return nil, err
+		}
+		return clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	}
 
 	if o.Object == nil {
 		builder := f.NewBuilder().
@@ -354,6 +392,19 @@ func (o LogsOptions) Validate() error {
 		return fmt.Errorf("--tail must be greater than or equal to -1")
 	}
 
+	if len(o.Archive) > 0 {
+		if o.Follow {
+			return fmt.Errorf("--archive cannot be used with --follow")
+		}
+		if o.Previous {
+			return fmt.Errorf("--archive cannot be used with --previous, previous logs are always included in the archive")
+		}
+	}
+
+	if o.MergeBuffer < 0 {
+		return fmt.Errorf("--merge-buffer must be greater than or equal to 0")
+	}
+
 	return nil
 }
 
@@ -370,6 +421,10 @@ func (o LogsOptions) RunLogsContext(ctx context.Context) error {
 	defer cancel()
 	intr := interrupt.New(nil, cancel)
 	return intr.Run(func() error {
+		if len(o.Archive) > 0 {
+			return o.runArchive(ctx)
+		}
+
 		var requests map[corev1.ObjectReference]rest.ResponseWrapper
 		var err error
 		if o.AllPods {
@@ -390,6 +445,12 @@ func (o LogsOptions) RunLogsContext(ctx context.Context) error {
 			}
 		}
 
+		if o.Merge {
+			if o.Follow {
+				return o.mergedFollowConsumeRequest(ctx, requests)
+			}
+			return o.mergedConsumeRequest(ctx, requests)
+		}
 		if o.Follow && len(requests) > 1 {
 			return o.parallelConsumeRequest(ctx, requests)
 		}
@@ -465,24 +526,37 @@ func (o LogsOptions) consumeWithRetry(ctx context.Context, request rest.Response
 }
 
 func (o LogsOptions) addPrefixIfNeeded(ref corev1.ObjectReference, writer io.Writer) io.Writer {
-	if !o.Prefix || ref.FieldPath == "" || ref.Name == "" {
+	prefix := o.logPrefix(ref)
+	if prefix == "" {
 		return writer
 	}
 
+	return &prefixingWriter{
+		prefix: []byte(prefix),
+		writer: writer,
+	}
+}
+
+// logPrefix returns the prefix identifying the log source of ref,
+// or an empty string if no prefix should be printed.
+func (o LogsOptions) logPrefix(ref corev1.ObjectReference) string {
+	if !o.Prefix || ref.FieldPath == "" || ref.Name == "" {
+		return ""
+	}
+
 	// We rely on ref.FieldPath to contain a reference to a container
 	// including a container name (not an index) so we can get a container name
 	// without making an extra API request.
-	var containerName string
-	containerNameMatches := o.containerNameFromRefSpecRegexp.FindStringSubmatch(ref.FieldPath)
-	if len(containerNameMatches) == 2 {
-		containerName = containerNameMatches[1]
-	}
+	return fmt.Sprintf("[pod/%s/%s] ", ref.Name, o.containerNameFromRef(ref))
+}
 
-	prefix := fmt.Sprintf("[pod/%s/%s] ", ref.Name, containerName)
-	return &prefixingWriter{
-		prefix: []byte(prefix),
-		writer: writer,
+// containerNameFromRef returns the name of the container ref points to.
+func (o LogsOptions) containerNameFromRef(ref corev1.ObjectReference) string {
+	matches := o.containerNameFromRefSpecRegexp.FindStringSubmatch(ref.FieldPath)
+	if len(matches) != 2 {
+		return ""
 	}
+	return matches[1]
 }
 
 // DefaultConsumeRequest reads the data from request and writes into
@@ -0,0 +1,240 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"bytes"
+	"container/heap"
+	"context"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/rest"
+)
+
+// logLine is a single line of log output together with the timestamp
+// the kubelet recorded for it.
+type logLine struct {
+	timestamp time.Time
+	// prefix identifies the log source, see LogsOptions.logPrefix.
+	prefix string
+	// rawTimestamp is the timestamp as it was sent by the server,
+	// including the trailing space, or empty if the line had none.
+	rawTimestamp []byte
+	message      []byte
+
+	// arrival and seq are used while following, to decide when a line
+	// can be released and to keep the order of equal timestamps stable.
+	arrival time.Time
+	seq     int
+}
+
+// lineSplitter is an io.Writer which splits the data written to it into
+// lines and hands each complete line, with its timestamp parsed, to emit.
+// Lines without a parsable timestamp inherit the timestamp of the previous
+// line of the same source so they stay next to it once sorted.
+type lineSplitter struct {
+	prefix string
+	emit   func(logLine)
+
+	buf  []byte
+	last time.Time
+}
+
+func (s *lineSplitter) Write(p []byte) (int, error) {
+	s.buf = append(s.buf, p...)
+	for {
+		i := bytes.IndexByte(s.buf, '\n')
+		if i < 0 {
+			break
+		}
+		s.emitLine(s.buf[:i+1])
+		s.buf = s.buf[i+1:]
+	}
+	return len(p), nil
+}
+
+// Flush emits any remaining data which was not terminated by a newline.
+func (s *lineSplitter) Flush() {
+	if len(s.buf) == 0 {
+		return
+	}
+	s.emitLine(append(s.buf, '\n'))
+	s.buf = nil
+}
+
+func (s *lineSplitter) emitLine(data []byte) {
+	line := logLine{prefix: s.prefix, timestamp: s.last}
+	if i := bytes.IndexByte(data, ' '); i > 0 {
+		if t, err := time.Parse(time.RFC3339Nano, string(data[:i])); err == nil {
+			line.timestamp = t
+			line.rawTimestamp = bytes.Clone(data[:i+1])
+			data = data[i+1:]
+			s.last = t
+		}
+	}
+	line.message = bytes.Clone(data)
+	s.emit(line)
+}
+
+// writeLogLine writes line to out, dropping the timestamp unless it was
+// explicitly requested with --timestamps.
+func (o LogsOptions) writeLogLine(out io.Writer, line logLine) error {
+	data := make([]byte, 0, len(line.prefix)+len(line.rawTimestamp)+len(line.message))
+	data = append(data, line.prefix...)
+	if o.Timestamps {
+		data = append(data, line.rawTimestamp...)
+	}
+	data = append(data, line.message...)
+	_, err := out.Write(data)
+	return err
+}
+
+// mergedConsumeRequest reads all requests to completion and prints
+// their lines sorted by timestamp.
+func (o LogsOptions) mergedConsumeRequest(ctx context.Context, requests map[corev1.ObjectReference]rest.ResponseWrapper) error {
+	var lines []logLine
+	for objRef, request := range requests {
+		splitter := &lineSplitter{
+			prefix: o.logPrefix(objRef),
+			emit:   func(line logLine) { lines = append(lines, line) },
+		}
+		if err := o.consumeWithRetry(ctx, request, splitter, o.ErrOut); err != nil {
+			return err
+		}
+		splitter.Flush()
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].timestamp.Before(lines[j].timestamp)
+	})
+	for _, line := range lines {
+		if err := o.writeLogLine(o.Out, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergedFollowConsumeRequest follows all requests concurrently and prints
+// their lines ordered by timestamp. Every line is held back for
+// MergeBuffer after it arrived, so lines of other sources with an earlier
+// timestamp which arrive within that window are printed before it.
+func (o LogsOptions) mergedFollowConsumeRequest(ctx context.Context, requests map[corev1.ObjectReference]rest.ResponseWrapper) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	received := make(chan logLine)
+	errs := make(chan error, len(requests))
+	wg := &sync.WaitGroup{}
+	wg.Add(len(requests))
+	for objRef, request := range requests {
+		go func(objRef corev1.ObjectReference, request rest.ResponseWrapper) {
+			defer wg.Done()
+			splitter := &lineSplitter{
+				prefix: o.logPrefix(objRef),
+				emit: func(line logLine) {
+					select {
+					case received <- line:
+					case <-ctx.Done():
+					}
+				},
+			}
+			if err := o.consumeWithRetry(ctx, request, splitter, o.ErrOut); err != nil {
+				errs <- err
+				cancel()
+				return
+			}
+			splitter.Flush()
+		}(objRef, request)
+	}
+
+	go func() {
+		wg.Wait()
+		close(received)
+	}()
+
+	pending := &logLineHeap{}
+	ticker := time.NewTicker(o.mergeTick())
+	defer ticker.Stop()
+	seq := 0
+	for {
+		select {
+		case line, ok := <-received:
+			if !ok {
+				for pending.Len() > 0 {
+					if err := o.writeLogLine(o.Out, heap.Pop(pending).(logLine)); err != nil {
+						return err
+					}
+				}
+				select {
+				case err := <-errs:
+					return err
+				default:
+					return nil
+				}
+			}
+			line.arrival = time.Now()
+			line.seq = seq
+			seq++
+			heap.Push(pending, line)
+		case now := <-ticker.C:
+			for pending.Len() > 0 && now.Sub((*pending)[0].arrival) >= o.MergeBuffer {
+				if err := o.writeLogLine(o.Out, heap.Pop(pending).(logLine)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// mergeTick returns how often held back lines are checked for release.
+func (o LogsOptions) mergeTick() time.Duration {
+	tick := o.MergeBuffer / 4
+	if tick < 10*time.Millisecond {
+		tick = 10 * time.Millisecond
+	}
+	return tick
+}
+
+// logLineHeap is a min-heap of log lines ordered by timestamp and, for
+// equal timestamps, by the order in which they were received.
+type logLineHeap []logLine
+
+func (h logLineHeap) Len() int { return len(h) }
+
+func (h logLineHeap) Less(i, j int) bool {
+	if h[i].timestamp.Equal(h[j].timestamp) {
+		return h[i].seq < h[j].seq
+	}
+	return h[i].timestamp.Before(h[j].timestamp)
+}
+
+func (h logLineHeap) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *logLineHeap) Push(x interface{}) { *h = append(*h, x.(logLine)) }
+
+func (h *logLineHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	x := old[n-1]
+	*h = old[:n-1]
+	return x
+}
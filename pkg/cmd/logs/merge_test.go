@@ -0,0 +1,125 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logs
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/cli-runtime/pkg/genericiooptions"
+	restclient "k8s.io/client-go/rest"
+)
+
+func mergeTestRequests() map[corev1.ObjectReference]restclient.ResponseWrapper {
+	return map[corev1.ObjectReference]restclient.ResponseWrapper{
+		{
+			Kind:      "Pod",
+			Name:      "some-pod-1",
+			FieldPath: "spec.containers{some-container}",
+		}: &statefulResponseWrapperMock{
+			results: []mockResult{
+				{data: strings.NewReader("2024-08-30T06:00:01.000000000Z line 2\n2024-08-30T06:00:03.000000000Z line 4\n")},
+			},
+		},
+		{
+			Kind:      "Pod",
+			Name:      "some-pod-2",
+			FieldPath: "spec.containers{some-container}",
+		}: &statefulResponseWrapperMock{
+			results: []mockResult{
+				{data: strings.NewReader("2024-08-30T06:00:00.000000000Z line 1\n2024-08-30T06:00:02.000000000Z line 3\ncontinued")},
+			},
+		},
+	}
+}
+
+func TestMergedLogs(t *testing.T) {
+	tests := []struct {
+		name        string
+		follow      bool
+		prefix      bool
+		timestamps  bool
+		expectedOut string
+	}{
+		{
+			name:        "merge without timestamps",
+			expectedOut: "line 1\nline 2\nline 3\ncontinued\nline 4\n",
+		},
+		{
+			name:       "merge with timestamps",
+			timestamps: true,
+			expectedOut: "2024-08-30T06:00:00.000000000Z line 1\n" +
+				"2024-08-30T06:00:01.000000000Z line 2\n" +
+				"2024-08-30T06:00:02.000000000Z line 3\n" +
+				"continued\n" +
+				"2024-08-30T06:00:03.000000000Z line 4\n",
+		},
+		{
+			name:   "merge with prefix",
+			prefix: true,
+			expectedOut: "[pod/some-pod-2/some-container] line 1\n" +
+				"[pod/some-pod-1/some-container] line 2\n" +
+				"[pod/some-pod-2/some-container] line 3\n" +
+				"[pod/some-pod-2/some-container] continued\n" +
+				"[pod/some-pod-1/some-container] line 4\n",
+		},
+		{
+			name:        "merge while following",
+			follow:      true,
+			expectedOut: "line 1\nline 2\nline 3\ncontinued\nline 4\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			streams, _, buf, _ := genericiooptions.NewTestIOStreams()
+			mock := &logTestMock{logsForObjectRequests: mergeTestRequests()}
+
+			o := NewLogsOptions(streams)
+			o.GetPodTimeout = 5 * time.Millisecond
+			o.LogsForObject = mock.mockLogsForObject
+			o.ConsumeRequestFn = mock.mockConsumeRequest
+			o.Object = testPod()
+			o.Options = &corev1.PodLogOptions{}
+			o.Merge = true
+			o.MergeBuffer = 50 * time.Millisecond
+			o.Follow = test.follow
+			o.Prefix = test.prefix
+			o.Timestamps = test.timestamps
+
+			if err := o.RunLogsContext(t.Context()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got := buf.String(); got != test.expectedOut {
+				t.Errorf("expected:\n%q\ngot:\n%q", test.expectedOut, got)
+			}
+		})
+	}
+}
+
+func TestMergeRequestsTimestamps(t *testing.T) {
+	o := NewLogsOptions(genericiooptions.NewTestIOStreamsDiscard())
+	o.Merge = true
+	logOptions, err := o.ToLogOptions()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !logOptions.Timestamps {
+		t.Errorf("expected timestamps to be requested when merging")
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"fmt"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+// NewFactoryForContext returns a factory for the named context of the
+// kubeconfig loaded by f, for commands which talk to several clusters.
+// The client flags of f, such as --as, --token or --request-timeout, are
+// carried over, only the context is replaced. The flags selecting the
+// server, cluster or user are rejected, as every context would then talk to
+// the same cluster or as the same user.
+func NewFactoryForContext(f Factory, context string) (Factory, error) {
+	loader := f.ToRawKubeConfigLoader()
+	rawConfig, err := loader.RawConfig()
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := rawConfig.Contexts[context]; !ok {
+		return nil, fmt.Errorf("context %q does not exist", context)
+	}
+
+	flags := genericclioptions.NewConfigFlags(true).WithDiscoveryBurst(300).WithDiscoveryQPS(50.0)
+	if parent := configFlagsOf(f); parent != nil {
+		if flag := contextOverrideFlag(parent); len(flag) > 0 {
+			return nil, fmt.Errorf("%s cannot be used with several contexts", flag)
+		}
+		copyConfigFlags(flags, parent)
+	} else {
+		kubeConfig := loader.ConfigAccess().GetExplicitFile()
+		flags.KubeConfig = &kubeConfig
+	}
+	flags.Context = &context
+	return NewFactory(NewMatchVersionFlags(flags)), nil
+}
+
+// configFlagsOf returns the config flags f was created from, or nil if f
+// was not created from config flags.
+func configFlagsOf(f Factory) *genericclioptions.ConfigFlags {
+	impl, ok := f.(*factoryImpl)
+	if !ok {
+		return nil
+	}
+	getter := impl.clientGetter
+	if matchVersionFlags, ok := getter.(*MatchVersionFlags); ok {
+		getter = matchVersionFlags.Delegate
+	}
+	flags, _ := getter.(*genericclioptions.ConfigFlags)
+	return flags
+}
+
+// contextOverrideFlag returns the name of the flag of flags overriding the
+// server, cluster or user of the contexts, if any.
+func contextOverrideFlag(flags *genericclioptions.ConfigFlags) string {
+	switch {
+	case flags.APIServer != nil && len(*flags.APIServer) > 0:
+		return "--server"
+	case flags.ClusterName != nil && len(*flags.ClusterName) > 0:
+		return "--cluster"
+	case flags.AuthInfoName != nil && len(*flags.AuthInfoName) > 0:
+		return "--user"
+	}
+	return ""
+}
+
+// copyConfigFlags copies the values of the client flags of from to to,
+// except the ones selecting the server, cluster or user of the context.
+// The clients cached by from are not copied.
+func copyConfigFlags(to, from *genericclioptions.ConfigFlags) {
+	to.CacheDir = from.CacheDir
+	to.KubeConfig = from.KubeConfig
+	to.Namespace = from.Namespace
+	to.TLSServerName = from.TLSServerName
+	to.Insecure = from.Insecure
+	to.CertFile = from.CertFile
+	to.KeyFile = from.KeyFile
+	to.CAFile = from.CAFile
+	to.BearerToken = from.BearerToken
+	to.Impersonate = from.Impersonate
+	to.ImpersonateUID = from.ImpersonateUID
+	to.ImpersonateGroup = from.ImpersonateGroup
+	to.Username = from.Username
+	to.Password = from.Password
+	to.Timeout = from.Timeout
+	to.DisableCompression = from.DisableCompression
+	to.WrapConfigFn = from.WrapConfigFn
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package util
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"k8s.io/cli-runtime/pkg/genericclioptions"
+)
+
+const contextsKubeConfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: one
+  cluster:
+    server: https://one.example.com
+- name: two
+  cluster:
+    server: https://two.example.com
+users:
+- name: admin
+  user:
+    token: admin-token
+contexts:
+- name: one
+  context:
+    cluster: one
+    user: admin
+- name: two
+  context:
+    cluster: two
+    user: admin
+current-context: one
+`
+
+func TestNewFactoryForContext(t *testing.T) {
+	kubeConfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeConfig, []byte(contextsKubeConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	impersonate := "alice"
+	impersonateGroup := []string{"developers"}
+	token := "alice-token"
+	timeout := "7s"
+	insecure := true
+	flags := genericclioptions.NewConfigFlags(false)
+	flags.KubeConfig = &kubeConfig
+	flags.Impersonate = &impersonate
+	flags.ImpersonateGroup = &impersonateGroup
+	flags.BearerToken = &token
+	flags.Timeout = &timeout
+	flags.Insecure = &insecure
+
+	f, err := NewFactoryForContext(NewFactory(NewMatchVersionFlags(flags)), "two")
+	if err != nil {
+		t.Fatal(err)
+	}
+	config, err := f.ToRESTConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if config.Host != "https://two.example.com" {
+		t.Errorf("expected the server of the context, got %q", config.Host)
+	}
+	if config.Impersonate.UserName != impersonate || len(config.Impersonate.Groups) != 1 || config.Impersonate.Groups[0] != "developers" {
+		t.Errorf("expected --as and --as-group to be carried over, got %#v", config.Impersonate)
+	}
+	if config.BearerToken != token {
+		t.Errorf("expected --token to be carried over, got %q", config.BearerToken)
+	}
+	if config.Timeout != 7*time.Second {
+		t.Errorf("expected --request-timeout to be carried over, got %v", config.Timeout)
+	}
+	if !config.Insecure {
+		t.Errorf("expected --insecure-skip-tls-verify to be carried over")
+	}
+
+	if _, err := NewFactoryForContext(NewFactory(NewMatchVersionFlags(flags)), "three"); err == nil {
+		t.Errorf("expected an error for a context which does not exist")
+	}
+}
+
+func TestNewFactoryForContextRejectsOverrides(t *testing.T) {
+	kubeConfig := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(kubeConfig, []byte(contextsKubeConfig), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name      string
+		set       func(flags *genericclioptions.ConfigFlags, value *string)
+		expectErr string
+	}{
+		{
+			name:      "server",
+			set:       func(flags *genericclioptions.ConfigFlags, value *string) { flags.APIServer = value },
+			expectErr: "--server cannot be used with several contexts",
+		},
+		{
+			name:      "cluster",
+			set:       func(flags *genericclioptions.ConfigFlags, value *string) { flags.ClusterName = value },
+			expectErr: "--cluster cannot be used with several contexts",
+		},
+		{
+			name:      "user",
+			set:       func(flags *genericclioptions.ConfigFlags, value *string) { flags.AuthInfoName = value },
+			expectErr: "--user cannot be used with several contexts",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := genericclioptions.NewConfigFlags(false)
+			flags.KubeConfig = &kubeConfig
+			value := "one"
+			tt.set(flags, &value)
+
+			_, err := NewFactoryForContext(NewFactory(NewMatchVersionFlags(flags)), "two")
+			if err == nil || err.Error() != tt.expectErr {
+				t.Errorf("expected error %q, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
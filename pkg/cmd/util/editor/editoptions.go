@@ -52,6 +52,7 @@ import (
 	"k8s.io/kubectl/pkg/cmd/util/editor/crlf"
 	"k8s.io/kubectl/pkg/scheme"
 	"k8s.io/kubectl/pkg/util"
+	"k8s.io/kubectl/pkg/util/openapi"
 )
 
 // EditOptions contains all the options for running edit cli command.
@@ -78,6 +79,14 @@ type EditOptions struct {
 
 	managedFields map[types.UID][]metav1.ManagedFieldsEntry
 
+	// Minimal leaves the status and the metadata populated by the server out
+	// of the edited objects, and StripDefaults the fields equal to their
+	// schema default.
+	Minimal       bool
+	StripDefaults bool
+	openAPISchema openapi.Resources
+	serverFields  map[string]map[string]interface{}
+
 	genericiooptions.IOStreams
 
 	Recorder            genericclioptions.Recorder
@@ -87,6 +96,23 @@ type EditOptions struct {
 
 	FieldManager string
 
+	// ServerSideApply submits the edited objects with server-side apply
+	// instead of a patch, so that conflicts with other field managers are
+	// reported instead of silently taking ownership of their fields.
+	ServerSideApply bool
+	ForceConflicts  bool
+
+	// Resume is a file saved by a previous edit, whose edits are saved again
+	// instead of launching the editor first.
+	Resume string
+
+	// EditHook is a program run instead of the editor, which receives the
+	// objects on its standard input and writes the edited objects, or a JSON
+	// patch to apply to them, on its standard output. Its edits are saved
+	// with the resource version of the objects it ran on, and it runs again
+	// on the objects modified since.
+	EditHook string
+
 	Subresource string
 }
 
@@ -176,6 +202,21 @@ func (o *EditOptions) Complete(f cmdutil.Factory, args []string, cmd *cobra.Comm
 		return fmt.Errorf("the edit mode doesn't support output the patch")
 	}
 
+	if len(o.Resume) > 0 {
+		if o.EditMode != NormalEditMode {
+			return fmt.Errorf("--resume is only supported when editing existing resources")
+		}
+		if len(args) > 0 || len(o.Filenames) > 0 || len(o.Kustomize) > 0 {
+			return fmt.Errorf("--resume cannot be used with resource arguments or --filename")
+		}
+		// the objects to edit are the ones of the saved file, as they are now
+		o.Filenames = []string{o.Resume}
+	}
+
+	if len(o.EditHook) == 0 {
+		o.EditHook = os.Getenv(hookEnv)
+	}
+
 	cmdNamespace, enforceNamespace, err := f.ToRawKubeConfigLoader().Namespace()
 	if err != nil {
 		return err
@@ -219,6 +260,13 @@ func (o *EditOptions) Complete(f cmdutil.Factory, args []string, cmd *cobra.Comm
 		return err
 	}
 
+	if o.StripDefaults {
+		o.openAPISchema, err = f.OpenAPISchema()
+		if err != nil {
+			return err
+		}
+	}
+
 	o.CmdNamespace = cmdNamespace
 	o.f = f
 
@@ -227,12 +275,27 @@ func (o *EditOptions) Complete(f cmdutil.Factory, args []string, cmd *cobra.Comm
 
 // Validate checks the EditOptions to see if there is sufficient information to run the command.
 func (o *EditOptions) Validate() error {
+	if o.ForceConflicts && !o.ServerSideApply {
+		return fmt.Errorf("--force-conflicts only works with --server-side")
+	}
+	if o.ServerSideApply && o.EditMode != NormalEditMode {
+		return fmt.Errorf("--server-side is only supported when editing existing resources")
+	}
+	if o.StripDefaults && !o.Minimal {
+		return fmt.Errorf("--strip-defaults only works with --minimal")
+	}
+	if o.Minimal && o.EditMode != NormalEditMode {
+		return fmt.Errorf("--minimal is only supported when editing existing resources")
+	}
 	return nil
 }
 
 // Run performs the execution
 func (o *EditOptions) Run() error {
-	edit := NewDefaultEditor(editorEnvs())
+	var edit fileEditor = NewDefaultEditor(editorEnvs())
+	if len(o.EditHook) > 0 {
+		edit = NewHookEditor(o.EditHook, o.ErrOut)
+	}
 	// editFn is invoked for each edit session (once with a list for normal edit, once for each individual resource in a edit-on-create invocation)
 	editFn := func(infos []*resource.Info) error {
 		var (
@@ -243,7 +306,14 @@ func (o *EditOptions) Run() error {
 			err      error
 		)
 
+		// the objects saved, skipped or reverted so far in this session,
+		// by their name
+		applied := map[string]*unstructured.Unstructured{}
+		dropped := map[string]string{}
+
+		resume := len(o.Resume) > 0
 		containsError := false
+		hookRetries := 0
 		// loop until we succeed or cancel editing
 		for {
 			// get the object we're going to serialize as input to the editor
@@ -280,6 +350,11 @@ func (o *EditOptions) Run() error {
 				if err := o.extractManagedFields(originalObj); err != nil {
 					return preservedFile(err, results.file, o.ErrOut)
 				}
+				if o.Minimal {
+					if err := o.extractServerFields(originalObj); err != nil {
+						return preservedFile(err, results.file, o.ErrOut)
+					}
+				}
 
 				if err := o.editPrinterOptions.PrintObj(originalObj, w); err != nil {
 					return preservedFile(err, results.file, o.ErrOut)
@@ -292,16 +367,29 @@ func (o *EditOptions) Run() error {
 				buf.Write(cmdutil.ManualStrip(edited))
 			}
 
+			if containsError && len(o.EditHook) > 0 {
+				// the hook is not run again on its own output
+				return o.hookFailed(&results)
+			}
+
 			// launch the editor
 			editedDiff := edited
-			edited, file, err = edit.LaunchTempFile(fmt.Sprintf("%s-edit-", filepath.Base(os.Args[0])), o.editPrinterOptions.ext, buf)
+			if resume {
+				// the edits of the resumed file are saved again first
+				resume = false
+				edited, file, err = resumeFile(o.Resume, fmt.Sprintf("%s-edit-", filepath.Base(os.Args[0])), o.editPrinterOptions.ext)
+			} else {
+				edited, file, err = edit.LaunchTempFile(fmt.Sprintf("%s-edit-", filepath.Base(os.Args[0])), o.editPrinterOptions.ext, buf)
+			}
 			if err != nil {
 				return preservedFile(err, results.file, o.ErrOut)
 			}
 
 			// If we're retrying the loop because of an error, and no change was made in the file, short-circuit
 			if containsError && bytes.Equal(cmdutil.StripComments(editedDiff), cmdutil.StripComments(edited)) {
-				return preservedFile(fmt.Errorf("%s", "Edit cancelled, no valid changes were saved."), file, o.ErrOut)
+				err := preservedFile(fmt.Errorf("%s", "Edit cancelled, no valid changes were saved."), file, o.ErrOut)
+				o.printResumeHint(file)
+				return err
 			}
 			// cleanup any file from the previous pass
 			if len(results.file) > 0 {
@@ -357,8 +445,42 @@ func (o *EditOptions) Run() error {
 
 			// not a syntax error as it turns out...
 			containsError = false
+
+			// leave out the objects skipped or reverted in the header
+			var reverting []string
+			for object, directive := range objectDirectives(edited) {
+				if _, found := dropped[object]; found {
+					continue
+				}
+				if directive == directiveRevert {
+					dropped[object] = statusReverted
+					reverting = append(reverting, object)
+				} else {
+					dropped[object] = statusSkipped
+				}
+			}
+			if len(dropped) > 0 {
+				var kept []*resource.Info
+				for _, info := range updatedInfos {
+					if _, found := dropped[objectName(info)]; !found {
+						kept = append(kept, info)
+					}
+				}
+				updatedInfos = kept
+			}
 			updatedVisitor := resource.InfoListVisitor(updatedInfos)
 
+			// the metadata populated by the server identifies the objects,
+			// so it is added back first when it was left out
+			if o.Minimal {
+				if err := o.restoreServerFields(updatedInfos); err != nil {
+					return preservedFile(err, file, o.ErrOut)
+				}
+				if err := o.restoreServerFields(infos); err != nil {
+					return preservedFile(err, file, o.ErrOut)
+				}
+			}
+
 			// we need to add back managedFields to both updated and original object
 			if err := o.restoreManagedFields(updatedInfos); err != nil {
 				return preservedFile(err, file, o.ErrOut)
@@ -379,7 +501,15 @@ func (o *EditOptions) Run() error {
 
 			switch o.EditMode {
 			case NormalEditMode:
-				err = o.visitToPatch(infos, updatedVisitor, &results)
+				err = o.revertObjects(infos, applied, reverting, &results)
+				if err != nil {
+					break
+				}
+				if o.ServerSideApply {
+					err = o.visitToApply(infos, updatedVisitor, &results)
+				} else {
+					err = o.visitToPatch(infos, updatedVisitor, &results)
+				}
 			case ApplyEditMode:
 				err = o.visitToApplyEditPatch(infos, updatedVisitor)
 			case EditBeforeCreateMode:
@@ -390,6 +520,27 @@ func (o *EditOptions) Run() error {
 			if err != nil {
 				return preservedFile(err, results.file, o.ErrOut)
 			}
+			for _, info := range results.applied {
+				applied[objectName(info)] = info.Object.(*unstructured.Unstructured)
+			}
+			for _, info := range infos {
+				if status, found := dropped[objectName(info)]; found {
+					results.addStatus(info, status)
+				}
+			}
+
+			// a hook is run again on the latest version of the objects which
+			// were modified while it ran
+			if len(o.EditHook) > 0 && len(results.conflicts) > 0 && len(results.conflicts) == results.retryable &&
+				results.notfound == 0 && len(results.edit) == 0 && hookRetries < maxHookRetries {
+				hookRetries++
+				if err := refreshObjects(results.conflicts); err != nil {
+					return preservedFile(err, file, o.ErrOut)
+				}
+				fmt.Fprintf(o.ErrOut, "Running the edit hook again on the latest version of the objects in conflict.\n")
+				infos = results.conflicts
+				continue
+			}
 
 			// Handle all possible errors
 			//
@@ -398,6 +549,7 @@ func (o *EditOptions) Run() error {
 			// 3. invalid: retry those on the spot by looping ie. reloading the editor
 			if results.retryable > 0 {
 				fmt.Fprintf(o.ErrOut, "You can run `%s replace -f %s` to try this update again.\n", filepath.Base(os.Args[0]), file)
+				o.printResumeHint(file)
 				return cmdutil.ErrExit
 			}
 			if results.notfound > 0 {
@@ -618,6 +770,12 @@ func encodeToJSON(obj runtime.Unstructured) ([]byte, error) {
 }
 
 func (o *EditOptions) visitToPatch(originalInfos []*resource.Info, patchVisitor resource.Visitor, results *editResults) error {
+	return o.patchObjects(originalInfos, patchVisitor, results, statusEdited)
+}
+
+// patchObjects patches the original objects into the visited ones, and
+// reports them with the given operation.
+func (o *EditOptions) patchObjects(originalInfos []*resource.Info, patchVisitor resource.Visitor, results *editResults, operation string) error {
 	err := patchVisitor.Visit(func(info *resource.Info, incomingErr error) error {
 		editObjUID, err := meta.NewAccessor().UID(info.Object)
 		if err != nil {
@@ -651,6 +809,7 @@ func (o *EditOptions) visitToPatch(originalInfos []*resource.Info, patchVisitor
 
 		if reflect.DeepEqual(originalJS, editedJS) {
 			// no edit, so just skip it.
+			results.addStatus(info, statusUnchanged)
 			printer, err := o.ToPrinter("skipped")
 			if err != nil {
 				return err
@@ -705,6 +864,15 @@ func (o *EditOptions) visitToPatch(originalInfos []*resource.Info, patchVisitor
 			}
 		}
 
+		if len(o.EditHook) > 0 && operation == statusEdited {
+			// the edits of a hook are only saved on the version of the object
+			// it ran on, so that it runs again on the objects modified since
+			patch, err = requireResourceVersion(patch, originalInfo.Object)
+			if err != nil {
+				return err
+			}
+		}
+
 		if o.OutputPatch {
 			fmt.Fprintf(o.Out, "Patch: %s\n", string(patch))
 		}
@@ -716,9 +884,98 @@ func (o *EditOptions) visitToPatch(originalInfos []*resource.Info, patchVisitor
 			Patch(info.Namespace, info.Name, patchType, patch, nil)
 		if err != nil {
 			fmt.Fprintln(o.ErrOut, results.addError(err, info))
+			results.addStatus(info, errorStatus(err))
 			return nil
 		}
 		info.Refresh(patched, true)
+		results.addStatus(info, operation)
+		results.applied = append(results.applied, info)
+		printer, err := o.ToPrinter(operation)
+		if err != nil {
+			return err
+		}
+		return printer.PrintObj(info.Object, o.Out)
+	})
+	return err
+}
+
+// visitToApply submits the edited objects with server-side apply. Conflicts
+// with other field managers are reported in the editor, like validation
+// errors, unless they are forced.
+func (o *EditOptions) visitToApply(originalInfos []*resource.Info, applyVisitor resource.Visitor, results *editResults) error {
+	err := applyVisitor.Visit(func(info *resource.Info, incomingErr error) error {
+		editObjUID, err := meta.NewAccessor().UID(info.Object)
+		if err != nil {
+			return err
+		}
+
+		var originalInfo *resource.Info
+		for _, i := range originalInfos {
+			originalObjUID, err := meta.NewAccessor().UID(i.Object)
+			if err != nil {
+				return err
+			}
+			if editObjUID == originalObjUID {
+				originalInfo = i
+				break
+			}
+		}
+		if originalInfo == nil {
+			return fmt.Errorf("no original object found for %#v", info.Object)
+		}
+
+		originalJS, err := encodeToJSON(originalInfo.Object.(runtime.Unstructured))
+		if err != nil {
+			return err
+		}
+
+		editedJS, err := encodeToJSON(info.Object.(runtime.Unstructured))
+		if err != nil {
+			return err
+		}
+
+		if reflect.DeepEqual(originalJS, editedJS) {
+			// no edit, so just skip it.
+			results.addStatus(info, statusUnchanged)
+			printer, err := o.ToPrinter("skipped")
+			if err != nil {
+				return err
+			}
+			return printer.PrintObj(info.Object, o.Out)
+		}
+
+		original := originalInfo.Object.(*unstructured.Unstructured)
+		edited := info.Object.(*unstructured.Unstructured)
+		if original.GetAPIVersion() != edited.GetAPIVersion() || original.GetKind() != edited.GetKind() || original.GetName() != edited.GetName() {
+			return fmt.Errorf("%s", "At least one of apiVersion, kind and name was changed")
+		}
+
+		data, err := runtime.Encode(unstructured.UnstructuredJSONScheme, applyConfiguration(edited, o.Subresource))
+		if err != nil {
+			return err
+		}
+
+		if o.OutputPatch {
+			fmt.Fprintf(o.Out, "Patch: %s\n", string(data))
+		}
+
+		options := metav1.PatchOptions{
+			Force:        &o.ForceConflicts,
+			FieldManager: o.FieldManager,
+		}
+		applied, err := resource.NewHelper(info.Client, info.Mapping).
+			WithFieldManager(o.FieldManager).
+			WithFieldValidation(o.ValidationDirective).
+			WithSubresource(o.Subresource).
+			Patch(info.Namespace, info.Name, types.ApplyPatchType, data, &options)
+		if err != nil {
+			fmt.Fprintln(o.ErrOut, results.addError(err, info))
+			results.addStatus(info, errorStatus(err))
+			return nil
+		}
+		info.Refresh(applied, true)
+		results.addStatus(info, statusEdited)
+		results.applied = append(results.applied, info)
 		printer, err := o.ToPrinter("edited")
 		if err != nil {
 			return err
@@ -728,6 +985,33 @@ func (o *EditOptions) visitToPatch(originalInfos []*resource.Info, patchVisitor
 	return err
 }
 
+// serverPopulatedFields are the metadata fields set by the server, which
+// are left out of the apply configuration.
+var serverPopulatedFields = []string{
+	"creationTimestamp",
+	"deletionGracePeriodSeconds",
+	"deletionTimestamp",
+	"generation",
+	"managedFields",
+	"resourceVersion",
+	"selfLink",
+	"uid",
+}
+
+// applyConfiguration returns the edited object without the fields populated
+// by the server, to be submitted with server-side apply. The status is only
+// kept when editing the status subresource.
+func applyConfiguration(obj *unstructured.Unstructured, subresource string) *unstructured.Unstructured {
+	obj = obj.DeepCopy()
+	for _, field := range serverPopulatedFields {
+		unstructured.RemoveNestedField(obj.Object, "metadata", field)
+	}
+	if subresource != "status" {
+		unstructured.RemoveNestedField(obj.Object, "status")
+	}
+	return obj
+}
+
 func (o *EditOptions) visitToCreate(createVisitor resource.Visitor) error {
 	err := createVisitor.Visit(func(info *resource.Info, incomingErr error) error {
 		obj, err := resource.NewHelper(info.Client, info.Mapping).
@@ -786,9 +1070,11 @@ type editReason struct {
 	other []string
 }
 
-// editHeader includes a list of reasons the edit must be retried
+// editHeader includes a list of reasons the edit must be retried, and the
+// status of each object when several are edited
 type editHeader struct {
 	reasons []editReason
+	objects []objectStatus
 }
 
 // writeTo outputs the current header information into a stream
@@ -817,6 +1103,16 @@ func (h *editHeader) writeTo(w io.Writer, editMode EditMode) error {
 		}
 		fmt.Fprintln(w, "#")
 	}
+	if len(h.objects) > 1 {
+		fmt.Fprint(w, `# The objects were saved as follows. Change the status of an object to [skip]
+# to leave it out of the next save, or to [revert] to restore it as it was
+# before editing.
+`)
+		for _, s := range h.objects {
+			fmt.Fprintf(w, "# [%s] %s\n", s.status, s.object)
+		}
+		fmt.Fprintln(w, "#")
+	}
 	return nil
 }
 
@@ -824,16 +1120,19 @@ func (h *editHeader) writeTo(w io.Writer, editMode EditMode) error {
 type editResults struct {
 	header    editHeader
 	retryable int
+	conflicts []*resource.Info
 	notfound  int
 	edit      []*resource.Info
+	applied   []*resource.Info
 	file      string
 }
 
+func (r *editResults) addStatus(info *resource.Info, status string) {
+	r.header.objects = append(r.header.objects, objectStatus{object: objectName(info), status: status})
+}
+
 func (r *editResults) addError(err error, info *resource.Info) string {
-	resourceString := info.Mapping.Resource.Resource
-	if len(info.Mapping.Resource.Group) > 0 {
-		resourceString = resourceString + "." + info.Mapping.Resource.Group
-	}
+	resourceString := resourceString(info)
 
 	switch {
 	case apierrors.IsInvalid(err):
@@ -850,15 +1149,47 @@ func (r *editResults) addError(err error, info *resource.Info) string {
 		}
 		r.header.reasons = append(r.header.reasons, reason)
 		return fmt.Sprintf("error: %s %q is invalid", resourceString, info.Name)
+	case len(fieldManagerConflicts(err)) > 0:
+		r.edit = append(r.edit, info)
+		reason := editReason{
+			head: fmt.Sprintf("%s %q has conflicts with other field managers, change the fields back or use --force-conflicts to take their ownership", resourceString, info.Name),
+		}
+		for _, cause := range fieldManagerConflicts(err) {
+			reason.other = append(reason.other, fmt.Sprintf("%s: %s", cause.Field, cause.Message))
+		}
+		r.header.reasons = append(r.header.reasons, reason)
+		return fmt.Sprintf("error: %s %q has conflicts with other field managers", resourceString, info.Name)
 	case apierrors.IsNotFound(err):
 		r.notfound++
 		return fmt.Sprintf("error: %s %q could not be found on the server", resourceString, info.Name)
 	default:
 		r.retryable++
+		if apierrors.IsConflict(err) {
+			r.conflicts = append(r.conflicts, info)
+		}
 		return fmt.Sprintf("error: %s %q could not be patched: %v", resourceString, info.Name, err)
 	}
 }
 
+// fieldManagerConflicts returns the conflicts with other field managers of a
+// server-side apply error.
+func fieldManagerConflicts(err error) []metav1.StatusCause {
+	if !apierrors.IsConflict(err) {
+		return nil
+	}
+	status, ok := err.(apierrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil
+	}
+	var conflicts []metav1.StatusCause
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type == metav1.CauseTypeFieldManagerConflict {
+			conflicts = append(conflicts, cause)
+		}
+	}
+	return conflicts
+}
+
 // preservedFile writes out a message about the provided file if it exists to the
 // provided output stream when an error happens. Used to notify the user where
 // their updates were preserved.
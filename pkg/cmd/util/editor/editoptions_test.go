@@ -17,11 +17,16 @@ limitations under the License.
 package editor
 
 import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
 	"reflect"
 	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -29,7 +34,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/cli-runtime/pkg/genericiooptions"
+	"k8s.io/cli-runtime/pkg/printers"
 	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/client-go/rest/fake"
 )
 
 func TestHashOnLineBreak(t *testing.T) {
@@ -472,3 +479,274 @@ func TestEditOptions_visitToPatch(t *testing.T) {
 		})
 	}
 }
+
+func TestEditOptions_visitToPatchRequiresResourceVersionWithHook(t *testing.T) {
+	tests := []struct {
+		name                    string
+		hook                    string
+		expectedResourceVersion interface{}
+	}{
+		{
+			name: "editor",
+		},
+		{
+			name:                    "hook",
+			hook:                    "sed s/2/3/",
+			expectedResourceVersion: "7",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fake.RESTClient{
+				NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+				Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+					header := http.Header{}
+					header.Set("Content-Type", runtime.ContentTypeJSON)
+					if req.Method != http.MethodPatch {
+						t.Fatalf("unexpected request %s %s", req.Method, req.URL.Path)
+					}
+					data, err := io.ReadAll(req.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					patch := map[string]interface{}{}
+					if err := json.Unmarshal(data, &patch); err != nil {
+						t.Fatal(err)
+					}
+					resourceVersion, _, _ := unstructured.NestedFieldNoCopy(patch, "metadata", "resourceVersion")
+					if resourceVersion != tt.expectedResourceVersion {
+						t.Errorf("expected the resource version %v in the patch, got %s", tt.expectedResourceVersion, data)
+					}
+					body, _ := json.Marshal(newThingy("myname", "3").Object)
+					return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(bytes.NewReader(body))}, nil
+				}),
+			}
+			info := func(obj *unstructured.Unstructured) *resource.Info {
+				return &resource.Info{Client: client, Mapping: unregMapping, Namespace: "ns", Name: obj.GetName(), Object: obj}
+			}
+
+			streams, _, _, _ := genericiooptions.NewTestIOStreams()
+			o := &EditOptions{
+				IOStreams: streams,
+				EditHook:  tt.hook,
+				ToPrinter: func(operation string) (printers.ResourcePrinter, error) {
+					return &printers.NamePrinter{Operation: operation}, nil
+				},
+			}
+			results := &editResults{}
+			err := o.visitToPatch([]*resource.Info{info(newThingy("myname", "2"))}, &testVisitor{updatedInfos: []*resource.Info{info(newThingy("myname", "3"))}}, results)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(results.applied) != 1 {
+				t.Errorf("expected the object to be saved, got %d", len(results.applied))
+			}
+		})
+	}
+}
+
+func newThingy(name, replicas string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "a/b",
+			"kind":       "d",
+			"metadata": map[string]interface{}{
+				"uid":               "12345",
+				"namespace":         "ns",
+				"name":              name,
+				"resourceVersion":   "7",
+				"creationTimestamp": "2026-01-01T00:00:00Z",
+			},
+			"spec":   map[string]interface{}{"replicas": replicas},
+			"status": map[string]interface{}{"replicas": "1"},
+		},
+	}
+}
+
+func TestApplyConfiguration(t *testing.T) {
+	obj := newThingy("myname", "2")
+	obj.SetManagedFields([]metav1.ManagedFieldsEntry{{Manager: "kubectl-edit"}})
+
+	expected := map[string]interface{}{
+		"apiVersion": "a/b",
+		"kind":       "d",
+		"metadata":   map[string]interface{}{"namespace": "ns", "name": "myname"},
+		"spec":       map[string]interface{}{"replicas": "2"},
+	}
+	if got := applyConfiguration(obj, "").Object; !reflect.DeepEqual(expected, got) {
+		t.Errorf("unexpected apply configuration %#v", got)
+	}
+
+	expected["status"] = map[string]interface{}{"replicas": "1"}
+	if got := applyConfiguration(obj, "status").Object; !reflect.DeepEqual(expected, got) {
+		t.Errorf("unexpected apply configuration of the status %#v", got)
+	}
+
+	if obj.GetResourceVersion() != "7" || len(obj.GetManagedFields()) != 1 {
+		t.Errorf("the edited object should not be modified")
+	}
+}
+
+func TestEditOptions_visitToApply(t *testing.T) {
+	conflict := apierrors.NewApplyConflict([]metav1.StatusCause{{
+		Type:    metav1.CauseTypeFieldManagerConflict,
+		Message: `conflict with "hpa-controller"`,
+		Field:   ".spec.replicas",
+	}}, `Apply failed with 1 conflict: conflict with "hpa-controller": .spec.replicas`).Status()
+	conflict.Kind = "Status"
+	conflict.APIVersion = "v1"
+
+	tests := []struct {
+		name          string
+		edited        *unstructured.Unstructured
+		force         bool
+		expectErr     string
+		expectOut     string
+		expectReasons []editReason
+	}{
+		{
+			name:      "applied",
+			edited:    newThingy("myname", "3"),
+			expectOut: "d.a/myname edited\n",
+		},
+		{
+			name:      "forced",
+			edited:    newThingy("myname", "5"),
+			force:     true,
+			expectOut: "d.a/myname edited\n",
+		},
+		{
+			name:   "conflict",
+			edited: newThingy("myname", "5"),
+			expectReasons: []editReason{{
+				head:  `c.a "myname" has conflicts with other field managers, change the fields back or use --force-conflicts to take their ownership`,
+				other: []string{`.spec.replicas: conflict with "hpa-controller"`},
+			}},
+		},
+		{
+			name:      "unchanged",
+			edited:    newThingy("myname", "2"),
+			expectOut: "d.a/myname skipped\n",
+		},
+		{
+			name:      "name changed",
+			edited:    newThingy("othername", "3"),
+			expectErr: "At least one of apiVersion, kind and name was changed",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fake.RESTClient{
+				NegotiatedSerializer: resource.UnstructuredPlusDefaultContentConfig().NegotiatedSerializer,
+				Client: fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+					header := http.Header{}
+					header.Set("Content-Type", runtime.ContentTypeJSON)
+					if req.Method != http.MethodPatch || req.Header.Get("Content-Type") != string(types.ApplyPatchType) {
+						t.Fatalf("unexpected request %s %s %s", req.Method, req.URL.Path, req.Header.Get("Content-Type"))
+					}
+					if force := req.URL.Query().Get("force"); (force == "true") != tt.force {
+						t.Errorf("unexpected force %q", force)
+					}
+					if manager := req.URL.Query().Get("fieldManager"); manager != "kubectl-edit" {
+						t.Errorf("unexpected field manager %q", manager)
+					}
+					data, err := io.ReadAll(req.Body)
+					if err != nil {
+						t.Fatal(err)
+					}
+					applied := map[string]interface{}{}
+					if err := json.Unmarshal(data, &applied); err != nil {
+						t.Fatal(err)
+					}
+					if _, found := applied["status"]; found {
+						t.Errorf("unexpected status in the apply configuration")
+					}
+					if tt.name == "conflict" {
+						body, _ := json.Marshal(conflict)
+						return &http.Response{StatusCode: http.StatusConflict, Header: header, Body: io.NopCloser(bytes.NewReader(body))}, nil
+					}
+					return &http.Response{StatusCode: http.StatusOK, Header: header, Body: io.NopCloser(bytes.NewReader(data))}, nil
+				}),
+			}
+			info := func(obj *unstructured.Unstructured) *resource.Info {
+				return &resource.Info{Client: client, Mapping: unregMapping, Namespace: "ns", Name: obj.GetName(), Object: obj}
+			}
+
+			streams, _, out, _ := genericiooptions.NewTestIOStreams()
+			o := &EditOptions{
+				IOStreams:       streams,
+				FieldManager:    "kubectl-edit",
+				ServerSideApply: true,
+				ForceConflicts:  tt.force,
+				ToPrinter: func(operation string) (printers.ResourcePrinter, error) {
+					return &printers.NamePrinter{Operation: operation}, nil
+				},
+			}
+			results := &editResults{}
+			err := o.visitToApply([]*resource.Info{info(newThingy("myname", "2"))}, &testVisitor{updatedInfos: []*resource.Info{info(tt.edited)}}, results)
+			switch {
+			case len(tt.expectErr) == 0 && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case len(tt.expectErr) > 0 && (err == nil || err.Error() != tt.expectErr):
+				t.Fatalf("expected error %q, got %v", tt.expectErr, err)
+			}
+			if out.String() != tt.expectOut {
+				t.Errorf("expected output %q, got %q", tt.expectOut, out.String())
+			}
+			if !reflect.DeepEqual(tt.expectReasons, results.header.reasons) {
+				t.Errorf("unexpected reasons %#v", results.header.reasons)
+			}
+			if len(tt.expectReasons) != len(results.edit) {
+				t.Errorf("expected %d objects to edit again, got %d", len(tt.expectReasons), len(results.edit))
+			}
+		})
+	}
+}
+
+func TestEditOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   EditOptions
+		expectErr string
+	}{
+		{
+			name:    "server-side",
+			options: EditOptions{EditMode: NormalEditMode, ServerSideApply: true, ForceConflicts: true},
+		},
+		{
+			name:      "force-conflicts without server-side",
+			options:   EditOptions{EditMode: NormalEditMode, ForceConflicts: true},
+			expectErr: "--force-conflicts only works with --server-side",
+		},
+		{
+			name:      "server-side before create",
+			options:   EditOptions{EditMode: EditBeforeCreateMode, ServerSideApply: true},
+			expectErr: "--server-side is only supported when editing existing resources",
+		},
+		{
+			name:    "minimal",
+			options: EditOptions{EditMode: NormalEditMode, Minimal: true, StripDefaults: true},
+		},
+		{
+			name:      "strip-defaults without minimal",
+			options:   EditOptions{EditMode: NormalEditMode, StripDefaults: true},
+			expectErr: "--strip-defaults only works with --minimal",
+		},
+		{
+			name:      "minimal last applied",
+			options:   EditOptions{EditMode: ApplyEditMode, Minimal: true},
+			expectErr: "--minimal is only supported when editing existing resources",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			switch {
+			case len(tt.expectErr) == 0 && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case len(tt.expectErr) > 0 && (err == nil || err.Error() != tt.expectErr):
+				t.Errorf("expected error %q, got %v", tt.expectErr, err)
+			}
+		})
+	}
+}
@@ -78,6 +78,12 @@ func defaultEnvEditor(envs []string) ([]string, bool) {
 	if len(editor) == 0 {
 		editor = platformize(defaultEditor, windowsEditor)
 	}
+	return editorArgs(editor)
+}
+
+// editorArgs returns the command line of the editor, and whether it is run
+// by the user's shell.
+func editorArgs(editor string) ([]string, bool) {
 	if !strings.Contains(editor, " ") {
 		return []string{editor}, false
 	}
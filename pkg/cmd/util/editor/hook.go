@@ -0,0 +1,192 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	jsonpatch "gopkg.in/evanphx/json-patch.v4"
+	"k8s.io/klog/v2"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/cli-runtime/pkg/resource"
+	cmdutil "k8s.io/kubectl/pkg/cmd/util"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// hookEnv is the environment variable holding the default edit hook.
+	hookEnv = "KUBE_EDIT_HOOK"
+	// maxHookRetries is the number of times the hook is run again on the
+	// objects modified concurrently.
+	maxHookRetries = 3
+)
+
+// fileEditor edits content saved to a temporary file.
+type fileEditor interface {
+	LaunchTempFile(prefix, suffix string, r io.Reader) ([]byte, string, error)
+}
+
+var _ fileEditor = Editor{}
+var _ fileEditor = HookEditor{}
+
+// HookEditor runs a program which edits the content without user
+// interaction, instead of an editor. The program receives the content on its
+// standard input, without comments, and writes on its standard output either
+// the edited content, or a JSON patch (RFC 6902) to apply to the content.
+type HookEditor struct {
+	Args  []string
+	Shell bool
+	// ErrOut receives the standard error of the program.
+	ErrOut io.Writer
+}
+
+// NewHookEditor creates a HookEditor running the given command line, which
+// is passed to the user's shell if it has quotes, like the editor.
+func NewHookEditor(hook string, errOut io.Writer) HookEditor {
+	args, shell := editorArgs(hook)
+	return HookEditor{
+		Args:   args,
+		Shell:  shell,
+		ErrOut: errOut,
+	}
+}
+
+// LaunchTempFile runs the program on the content read from r, and saves the
+// edited content into a temporary file with the given prefix and suffix. It
+// returns the edited content and the path of the file, like Editor.
+func (e HookEditor) LaunchTempFile(prefix, suffix string, r io.Reader) ([]byte, string, error) {
+	input, err := io.ReadAll(r)
+	if err != nil {
+		return nil, "", err
+	}
+	input = cmdutil.ManualStrip(input)
+
+	output, err := e.run(input)
+	if err != nil {
+		return nil, "", err
+	}
+	edited, err := applyHookOutput(input, output, suffix)
+	if err != nil {
+		return nil, "", fmt.Errorf("the JSON patch returned by the edit hook %q could not be applied: %v", strings.Join(e.Args, " "), err)
+	}
+
+	f, err := os.CreateTemp("", prefix+"*"+suffix)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+	if _, err := f.Write(edited); err != nil {
+		os.Remove(f.Name())
+		return nil, "", err
+	}
+	return edited, f.Name(), nil
+}
+
+func (e HookEditor) run(input []byte) ([]byte, error) {
+	if len(e.Args) == 0 {
+		return nil, fmt.Errorf("no edit hook defined")
+	}
+	stdout := &bytes.Buffer{}
+	cmd := exec.Command(e.Args[0], e.Args[1:]...)
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = e.ErrOut
+	klog.V(5).Infof("Running edit hook %v", e.Args)
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("the edit hook %q failed: %v", strings.Join(e.Args, " "), err)
+	}
+	return stdout.Bytes(), nil
+}
+
+// applyHookOutput returns the edited content from the output of a hook:
+// the output itself, unless it is a JSON patch, which is applied to the
+// input instead. The patched content is converted back to YAML unless the
+// content is edited as JSON.
+func applyHookOutput(input, output []byte, suffix string) ([]byte, error) {
+	trimmed := bytes.TrimSpace(output)
+	if !bytes.HasPrefix(trimmed, []byte("[")) {
+		return output, nil
+	}
+	patch, err := jsonpatch.DecodePatch(trimmed)
+	if err != nil {
+		return nil, err
+	}
+	doc, err := utilyaml.ToJSON(input)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := patch.Apply(doc)
+	if err != nil {
+		return nil, err
+	}
+	if suffix == ".json" {
+		return patched, nil
+	}
+	return yaml.JSONToYAML(patched)
+}
+
+// hookFailed reports the reasons why the objects edited by the hook were not
+// saved, instead of reopening the file in the editor.
+func (o *EditOptions) hookFailed(results *editResults) error {
+	for _, r := range results.header.reasons {
+		fmt.Fprintf(o.ErrOut, "error: %s\n", r.head)
+		for _, other := range r.other {
+			fmt.Fprintf(o.ErrOut, "* %s\n", other)
+		}
+	}
+	err := preservedFile(fmt.Errorf("the objects edited by the hook were not saved"), results.file, o.ErrOut)
+	o.printResumeHint(results.file)
+	return err
+}
+
+// refreshObjects gets the latest version of the objects from the server.
+func refreshObjects(infos []*resource.Info) error {
+	for _, info := range infos {
+		if err := info.Get(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// requireResourceVersion adds the resource version of obj to patch, so that
+// the server rejects the patch with a conflict if the object was modified
+// since it was read, instead of merging the edits into the newer version.
+func requireResourceVersion(patch []byte, obj runtime.Object) ([]byte, error) {
+	resourceVersion, err := meta.NewAccessor().ResourceVersion(obj)
+	if err != nil || len(resourceVersion) == 0 {
+		return patch, err
+	}
+	patchMap := map[string]interface{}{}
+	if err := json.Unmarshal(patch, &patchMap); err != nil {
+		return nil, err
+	}
+	if err := unstructured.SetNestedField(patchMap, resourceVersion, "metadata", "resourceVersion"); err != nil {
+		return nil, err
+	}
+	return json.Marshal(patchMap)
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"bytes"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewHookEditor(t *testing.T) {
+	if e, a := []string{"yq"}, NewHookEditor("yq", nil); !reflect.DeepEqual(e, a.Args) || a.Shell {
+		t.Errorf("unexpected hook editor: %#v", a)
+	}
+	if e, a := []string{"sed", "s/a/b/"}, NewHookEditor("sed s/a/b/", nil); !reflect.DeepEqual(e, a.Args) || a.Shell {
+		t.Errorf("unexpected hook editor: %#v", a)
+	}
+	if a := NewHookEditor("yq '.spec.replicas = 3'", nil); !a.Shell || a.Args[len(a.Args)-1] != "yq '.spec.replicas = 3'" {
+		t.Errorf("unexpected hook editor: %#v", a)
+	}
+}
+
+func TestApplyHookOutput(t *testing.T) {
+	input := []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm1\ndata:\n  a: b\n")
+	tests := []struct {
+		name     string
+		output   string
+		suffix   string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "edited object",
+			output:   "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm1\n",
+			suffix:   ".yaml",
+			expected: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: cm1\n",
+		},
+		{
+			name:     "json patch to yaml",
+			output:   `[{"op": "replace", "path": "/data/a", "value": "c"}]` + "\n",
+			suffix:   ".yaml",
+			expected: "apiVersion: v1\ndata:\n  a: c\nkind: ConfigMap\nmetadata:\n  name: cm1\n",
+		},
+		{
+			name:     "json patch to json",
+			output:   `[{"op": "remove", "path": "/data"}]`,
+			suffix:   ".json",
+			expected: `{"apiVersion":"v1","kind":"ConfigMap","metadata":{"name":"cm1"}}`,
+		},
+		{
+			name:    "invalid json patch",
+			output:  `[{"op": "remove", "path": "/spec"}]`,
+			suffix:  ".yaml",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edited, err := applyHookOutput(input, []byte(tt.output), tt.suffix)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !tt.wantErr && string(edited) != tt.expected {
+				t.Errorf("unexpected edited content:\n%s", edited)
+			}
+		})
+	}
+}
+
+func TestHookEditor(t *testing.T) {
+	errOut := &bytes.Buffer{}
+	edit := HookEditor{Args: []string{"cat"}, ErrOut: errOut}
+	contents, path, err := edit.LaunchTempFile("someprefix", ".yaml", bytes.NewBufferString("# a comment\ntest something\n"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	defer os.Remove(path)
+	if disk, err := os.ReadFile(path); err != nil || !bytes.Equal(contents, disk) {
+		t.Errorf("unexpected file on disk: %v %s", err, string(disk))
+	}
+	if !bytes.Equal(contents, []byte("test something\n")) {
+		t.Errorf("unexpected contents: %s", string(contents))
+	}
+	if !strings.Contains(path, "someprefix") {
+		t.Errorf("path not expected: %s", path)
+	}
+
+	edit = HookEditor{Args: []string{"false"}, ErrOut: errOut}
+	if _, _, err := edit.LaunchTempFile("someprefix", ".yaml", bytes.NewBufferString("test\n")); err == nil || !strings.Contains(err.Error(), "the edit hook \"false\" failed") {
+		t.Errorf("expected the hook to fail, got %v", err)
+	}
+}
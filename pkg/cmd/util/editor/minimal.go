@@ -0,0 +1,164 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+// extractServerFields removes the status and the metadata populated by the
+// server from the objects before they are edited, and the fields equal to
+// their schema default if asked to. The metadata is kept to be restored once
+// edited, while the status and the defaults are left out of both the original
+// and the edited objects, so that the patch only holds the changes made.
+func (o *EditOptions) extractServerFields(obj runtime.Object) error {
+	o.serverFields = map[string]map[string]interface{}{}
+	if meta.IsListType(obj) {
+		return meta.EachListItem(obj, o.clearServerFields)
+	}
+	return o.clearServerFields(obj)
+}
+
+func (o *EditOptions) clearServerFields(obj runtime.Object) error {
+	u, ok := obj.(*unstructured.Unstructured)
+	if !ok {
+		return fmt.Errorf("unexpected object %T", obj)
+	}
+	metadata, found, err := unstructured.NestedMap(u.Object, "metadata")
+	if err != nil || !found {
+		return err
+	}
+	fields := map[string]interface{}{}
+	for _, field := range serverPopulatedFields {
+		if value, found := metadata[field]; found && field != "managedFields" {
+			fields[field] = value
+			unstructured.RemoveNestedField(u.Object, "metadata", field)
+		}
+	}
+	o.serverFields[serverFieldsKey(u)] = fields
+
+	if o.Subresource != "status" {
+		unstructured.RemoveNestedField(u.Object, "status")
+	}
+	if o.StripDefaults && o.openAPISchema != nil {
+		if s := o.openAPISchema.LookupResource(u.GroupVersionKind()); s != nil {
+			stripDefaults(u.Object, s)
+		}
+	}
+	return nil
+}
+
+// restoreServerFields restores the metadata populated by the server, which
+// identifies the objects, unless it was set while editing.
+func (o *EditOptions) restoreServerFields(infos []*resource.Info) error {
+	for _, info := range infos {
+		u, ok := info.Object.(*unstructured.Unstructured)
+		if !ok {
+			return fmt.Errorf("unexpected object %T", info.Object)
+		}
+		for field, value := range o.serverFields[serverFieldsKey(u)] {
+			if _, found, _ := unstructured.NestedFieldNoCopy(u.Object, "metadata", field); found {
+				continue
+			}
+			if err := unstructured.SetNestedField(u.Object, runtime.DeepCopyJSONValue(value), "metadata", field); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func serverFieldsKey(u *unstructured.Unstructured) string {
+	return fmt.Sprintf("%s/%s/%s", u.GroupVersionKind().GroupKind(), u.GetNamespace(), u.GetName())
+}
+
+// stripDefaults removes the fields of obj which are equal to the default
+// value of their schema.
+func stripDefaults(obj map[string]interface{}, s proto.Schema) {
+	kind, ok := resolveSchema(s).(*proto.Kind)
+	if !ok {
+		return
+	}
+	for name, value := range obj {
+		field, found := kind.Fields[name]
+		if !found {
+			continue
+		}
+		if isDefault(value, field) {
+			delete(obj, name)
+			continue
+		}
+		stripDefaultsValue(value, field)
+	}
+}
+
+func stripDefaultsValue(v interface{}, s proto.Schema) {
+	switch t := resolveSchema(s).(type) {
+	case *proto.Kind:
+		if m, ok := v.(map[string]interface{}); ok {
+			stripDefaults(m, t)
+		}
+	case *proto.Array:
+		if items, ok := v.([]interface{}); ok {
+			for _, item := range items {
+				stripDefaultsValue(item, t.SubType)
+			}
+		}
+	case *proto.Map:
+		if m, ok := v.(map[string]interface{}); ok {
+			for _, item := range m {
+				stripDefaultsValue(item, t.SubType)
+			}
+		}
+	}
+}
+
+// isDefault returns whether v is the default value of s, or of the schema
+// it references.
+func isDefault(v interface{}, s proto.Schema) bool {
+	for s != nil {
+		if def := s.GetDefault(); def != nil {
+			a, errA := json.Marshal(v)
+			b, errB := json.Marshal(def)
+			return errA == nil && errB == nil && bytes.Equal(a, b)
+		}
+		ref, ok := s.(proto.Reference)
+		if !ok {
+			return false
+		}
+		s = ref.SubSchema()
+	}
+	return false
+}
+
+func resolveSchema(s proto.Schema) proto.Schema {
+	for {
+		ref, ok := s.(proto.Reference)
+		if !ok {
+			return s
+		}
+		s = ref.SubSchema()
+	}
+}
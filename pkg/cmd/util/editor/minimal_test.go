@@ -0,0 +1,163 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+	"k8s.io/kube-openapi/pkg/util/proto"
+)
+
+type fakeResources struct {
+	schemas map[schema.GroupVersionKind]proto.Schema
+}
+
+func (r fakeResources) LookupResource(gvk schema.GroupVersionKind) proto.Schema {
+	return r.schemas[gvk]
+}
+
+func (r fakeResources) GetConsumes(gvk schema.GroupVersionKind, operation string) []string {
+	return nil
+}
+
+// deploymentSchema describes a deployment defaulting its replicas and the
+// pull policy of its containers.
+func deploymentSchema() proto.Schema {
+	container := &proto.Kind{Fields: map[string]proto.Schema{
+		"name":            &proto.Primitive{Type: "string"},
+		"imagePullPolicy": &proto.Primitive{BaseSchema: proto.BaseSchema{Default: "IfNotPresent"}, Type: "string"},
+	}}
+	return &proto.Kind{Fields: map[string]proto.Schema{
+		"spec": &proto.Kind{Fields: map[string]proto.Schema{
+			"replicas": &proto.Primitive{BaseSchema: proto.BaseSchema{Default: 1}, Type: "integer"},
+			"template": &proto.Kind{Fields: map[string]proto.Schema{
+				"spec": &proto.Kind{Fields: map[string]proto.Schema{
+					"containers": &proto.Array{SubType: container},
+				}},
+			}},
+		}},
+	}}
+}
+
+func newDeployment(name string, replicas int64) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":              name,
+			"namespace":         "ns",
+			"uid":               "uid-" + name,
+			"resourceVersion":   "7",
+			"generation":        int64(2),
+			"creationTimestamp": "2026-01-01T00:00:00Z",
+			"labels":            map[string]interface{}{"app": name},
+		},
+		"spec": map[string]interface{}{
+			"replicas": replicas,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web", "imagePullPolicy": "IfNotPresent"},
+						map[string]interface{}{"name": "proxy", "imagePullPolicy": "Always"},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{"replicas": replicas},
+	}}
+}
+
+func TestExtractAndRestoreServerFields(t *testing.T) {
+	o := &EditOptions{
+		Minimal:       true,
+		StripDefaults: true,
+		openAPISchema: fakeResources{schemas: map[schema.GroupVersionKind]proto.Schema{
+			{Group: "apps", Version: "v1", Kind: "Deployment"}: deploymentSchema(),
+		}},
+	}
+	web, db := newDeployment("web", 1), newDeployment("db", 3)
+	list := &unstructured.UnstructuredList{Object: map[string]interface{}{"kind": "List", "apiVersion": "v1"}}
+	list.Items = []unstructured.Unstructured{*web, *db}
+
+	if err := o.extractServerFields(list); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":      "db",
+			"namespace": "ns",
+			"labels":    map[string]interface{}{"app": "db"},
+		},
+		"spec": map[string]interface{}{
+			"replicas": int64(3),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "web"},
+						map[string]interface{}{"name": "proxy", "imagePullPolicy": "Always"},
+					},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(expected, db.Object) {
+		t.Errorf("unexpected edited object %#v", db.Object)
+	}
+	if _, found, _ := unstructured.NestedFieldNoCopy(web.Object, "spec", "replicas"); found {
+		t.Errorf("expected the default replicas to be left out")
+	}
+
+	edited := newDeployment("db", 5)
+	unstructured.RemoveNestedField(edited.Object, "status")
+	for _, field := range []string{"uid", "resourceVersion", "generation", "creationTimestamp"} {
+		unstructured.RemoveNestedField(edited.Object, "metadata", field)
+	}
+	edited.SetResourceVersion("8")
+	if err := o.restoreServerFields([]*resource.Info{{Object: edited}}); err != nil {
+		t.Fatal(err)
+	}
+	if edited.GetUID() != "uid-db" || edited.GetGeneration() != 2 || edited.GetCreationTimestamp().IsZero() {
+		t.Errorf("expected the metadata to be restored, got %#v", edited.Object["metadata"])
+	}
+	if edited.GetResourceVersion() != "8" {
+		t.Errorf("expected the edited resource version to be kept, got %q", edited.GetResourceVersion())
+	}
+	if _, found := edited.Object["status"]; found {
+		t.Errorf("expected the status to be left out")
+	}
+}
+
+func TestExtractServerFieldsOfStatus(t *testing.T) {
+	o := &EditOptions{Minimal: true, Subresource: "status"}
+	obj := newDeployment("web", 1)
+	if err := o.extractServerFields(obj); err != nil {
+		t.Fatal(err)
+	}
+	if _, found := obj.Object["status"]; !found {
+		t.Errorf("expected the status to be kept when editing the status subresource")
+	}
+	if len(obj.GetUID()) > 0 {
+		t.Errorf("expected the uid to be left out")
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+// Statuses of the objects of a multi-object edit, shown in the header of
+// the reopened file.
+const (
+	statusEdited    = "edited"
+	statusUnchanged = "unchanged"
+	statusInvalid   = "invalid"
+	statusConflict  = "conflict"
+	statusNotFound  = "not found"
+	statusFailed    = "failed"
+	statusSkipped   = "skipped"
+	statusReverted  = "reverted"
+)
+
+// Directives which can replace the status of an object in the header of the
+// reopened file.
+const (
+	directiveSkip   = "skip"
+	directiveRevert = "revert"
+)
+
+// objectDirective matches a status line of the header changed to a directive.
+var objectDirective = regexp.MustCompile(`^#\s*\[(` + directiveSkip + `|` + directiveRevert + `)\]\s+(\S+)(?:\s+-n\s+(\S+))?$`)
+
+// objectStatus is the outcome of saving an object of a multi-object edit.
+type objectStatus struct {
+	object string
+	status string
+}
+
+// objectName returns the name of the object of info as RESOURCE[.GROUP]/NAME,
+// followed by -n NAMESPACE for namespaced objects, as the objects of an edit
+// may come from several namespaces.
+func objectName(info *resource.Info) string {
+	name := resourceString(info) + "/" + info.Name
+	if len(info.Namespace) > 0 {
+		name += " -n " + info.Namespace
+	}
+	return name
+}
+
+func resourceString(info *resource.Info) string {
+	resourceString := info.Mapping.Resource.Resource
+	if len(info.Mapping.Resource.Group) > 0 {
+		resourceString = resourceString + "." + info.Mapping.Resource.Group
+	}
+	return resourceString
+}
+
+// errorStatus returns the status of an object which could not be saved.
+func errorStatus(err error) string {
+	switch {
+	case apierrors.IsInvalid(err):
+		return statusInvalid
+	case len(fieldManagerConflicts(err)) > 0:
+		return statusConflict
+	case apierrors.IsNotFound(err):
+		return statusNotFound
+	default:
+		return statusFailed
+	}
+}
+
+// objectDirectives returns the objects to skip or revert, as requested by
+// changing their status in the header of the edited file.
+func objectDirectives(edited []byte) map[string]string {
+	directives := map[string]string{}
+	s := bufio.NewScanner(bytes.NewReader(edited))
+	for s.Scan() {
+		if m := objectDirective.FindStringSubmatch(strings.TrimSpace(s.Text())); m != nil {
+			object := m[2]
+			if len(m[3]) > 0 {
+				object += " -n " + m[3]
+			}
+			directives[object] = m[1]
+		}
+	}
+	return directives
+}
+
+// revertObject returns the original object, as it was before editing, with
+// the metadata populated by the server and the status of the current object,
+// so that patching current with it only reverts the edits.
+func revertObject(original, current *unstructured.Unstructured) *unstructured.Unstructured {
+	reverted := original.DeepCopy()
+	for _, field := range serverPopulatedFields {
+		value, found, _ := unstructured.NestedFieldCopy(current.Object, "metadata", field)
+		if !found {
+			unstructured.RemoveNestedField(reverted.Object, "metadata", field)
+			continue
+		}
+		unstructured.SetNestedField(reverted.Object, value, "metadata", field)
+	}
+	if status, found, _ := unstructured.NestedFieldCopy(current.Object, "status"); found {
+		unstructured.SetNestedField(reverted.Object, status, "status")
+	} else {
+		unstructured.RemoveNestedField(reverted.Object, "status")
+	}
+	return reverted
+}
+
+// revertObjects patches the objects edited earlier in the session back to
+// their original version.
+func (o *EditOptions) revertObjects(originalInfos []*resource.Info, applied map[string]*unstructured.Unstructured, objects []string, results *editResults) error {
+	for _, object := range objects {
+		current, found := applied[object]
+		if !found {
+			// the object was not saved, so there is nothing to revert
+			continue
+		}
+		for _, info := range originalInfos {
+			if objectName(info) != object {
+				continue
+			}
+			currentInfo := *info
+			currentInfo.Object = current.DeepCopy()
+			revertedInfo := *info
+			revertedInfo.Object = revertObject(info.Object.(*unstructured.Unstructured), current)
+			if err := o.patchObjects([]*resource.Info{&currentInfo}, resource.InfoListVisitor([]*resource.Info{&revertedInfo}), results, statusReverted); err != nil {
+				return err
+			}
+			delete(applied, object)
+		}
+	}
+	return nil
+}
+
+// resumeFile copies the file of a previous edit to a new temporary file,
+// which is then handled like the file saved by the editor.
+func resumeFile(path, prefix, suffix string) ([]byte, string, error) {
+	edited, err := os.ReadFile(path)
+	if err != nil {
+		return nil, "", err
+	}
+	f, err := os.CreateTemp("", prefix+"*"+suffix)
+	if err != nil {
+		return nil, "", err
+	}
+	defer f.Close()
+	if _, err := f.Write(edited); err != nil {
+		os.Remove(f.Name())
+		return nil, "", err
+	}
+	return edited, f.Name(), nil
+}
+
+// printResumeHint tells how to resume editing from the preserved file.
+func (o *EditOptions) printResumeHint(file string) {
+	if o.EditMode != NormalEditMode || len(file) == 0 {
+		return
+	}
+	if _, err := os.Stat(file); err != nil {
+		return
+	}
+	fmt.Fprintf(o.ErrOut, "You can run `%s edit --resume=%s` to edit the objects again from your changes.\n", filepath.Base(os.Args[0]), file)
+}
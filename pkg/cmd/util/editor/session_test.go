@@ -0,0 +1,160 @@
+/*
+Copyright 2026 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package editor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/cli-runtime/pkg/resource"
+)
+
+func TestObjectDirectives(t *testing.T) {
+	edited := []byte(`# Please edit the object below.
+#
+# The objects were saved as follows. Change the status of an object to [skip]
+# to leave it out of the next save, or to [revert] to restore it as it was
+# before editing.
+# [invalid] services/svc1
+# [skip] deployments.apps/web
+#   [revert]   configmaps/cm1
+# [revert] configmaps/cm2 please
+# [skip] configmaps/cm3 -n test
+# [revert] configmaps/cm3   -n   prod
+#
+apiVersion: v1
+kind: List
+`)
+	expected := map[string]string{
+		"deployments.apps/web":   directiveSkip,
+		"configmaps/cm1":         directiveRevert,
+		"configmaps/cm3 -n test": directiveSkip,
+		"configmaps/cm3 -n prod": directiveRevert,
+	}
+	if got := objectDirectives(edited); !reflect.DeepEqual(expected, got) {
+		t.Errorf("unexpected directives %v", got)
+	}
+}
+
+func TestWriteObjectStatuses(t *testing.T) {
+	header := editHeader{objects: []objectStatus{
+		{object: "services/svc1", status: statusInvalid},
+		{object: "deployments.apps/web", status: statusEdited},
+	}}
+	buf := &bytes.Buffer{}
+	if err := header.writeTo(buf, NormalEditMode); err != nil {
+		t.Fatal(err)
+	}
+	expected := `# [invalid] services/svc1
+# [edited] deployments.apps/web
+#
+`
+	if !strings.HasSuffix(buf.String(), expected) {
+		t.Errorf("expected the statuses of the objects in the header, got:\n%s", buf.String())
+	}
+
+	header.objects = header.objects[:1]
+	buf.Reset()
+	if err := header.writeTo(buf, NormalEditMode); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "[invalid]") {
+		t.Errorf("expected no status for a single object, got:\n%s", buf.String())
+	}
+}
+
+func TestObjectName(t *testing.T) {
+	info := &resource.Info{
+		Name: "web",
+		Mapping: &meta.RESTMapping{
+			Resource: schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"},
+		},
+	}
+	if name := objectName(info); name != "deployments.apps/web" {
+		t.Errorf("unexpected name %q", name)
+	}
+
+	info.Namespace = "test"
+	if name := objectName(info); name != "deployments.apps/web -n test" {
+		t.Errorf("unexpected name %q", name)
+	}
+	if directives := objectDirectives([]byte("# [skip] " + objectName(info) + "\n")); directives[objectName(info)] != directiveSkip {
+		t.Errorf("expected the name to be read back from the header, got %v", directives)
+	}
+}
+
+func TestRevertObject(t *testing.T) {
+	original := newDeployment("web", 1)
+	current := newDeployment("web", 3)
+	current.SetResourceVersion("9")
+	current.SetGeneration(3)
+	current.SetLabels(map[string]string{"app": "web", "tier": "frontend"})
+	reverted := revertObject(original, current)
+
+	if reverted.GetResourceVersion() != "9" || reverted.GetGeneration() != 3 {
+		t.Errorf("expected the metadata of the current object, got %#v", reverted.Object["metadata"])
+	}
+	if !reflect.DeepEqual(current.Object["status"], reverted.Object["status"]) {
+		t.Errorf("expected the status of the current object, got %#v", reverted.Object["status"])
+	}
+	if !reflect.DeepEqual(original.Object["spec"], reverted.Object["spec"]) {
+		t.Errorf("expected the spec of the original object, got %#v", reverted.Object["spec"])
+	}
+	if !reflect.DeepEqual(original.GetLabels(), reverted.GetLabels()) {
+		t.Errorf("expected the labels of the original object, got %v", reverted.GetLabels())
+	}
+	if original.GetResourceVersion() != "7" {
+		t.Errorf("the original object should not be modified")
+	}
+}
+
+func TestResumeFile(t *testing.T) {
+	saved := filepath.Join(t.TempDir(), "kubectl-edit-1.yaml")
+	content := []byte("# [skip] services/svc1\napiVersion: v1\nkind: List\n")
+	if err := os.WriteFile(saved, content, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	edited, file, err := resumeFile(saved, "kubectl-edit-", ".yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file)
+	if !bytes.Equal(content, edited) {
+		t.Errorf("unexpected content %q", edited)
+	}
+	if file == saved || !strings.HasSuffix(file, ".yaml") {
+		t.Errorf("expected a new temporary file, got %q", file)
+	}
+	copied, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(content, copied) {
+		t.Errorf("unexpected copied content %q", copied)
+	}
+
+	if _, _, err := resumeFile(filepath.Join(t.TempDir(), "missing.yaml"), "kubectl-edit-", ".yaml"); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+}